@@ -1,9 +1,87 @@
 -- backend/db/schema.sql
+-- Итоговая схема для справки. Изменения вносятся только миграциями
+-- в internal/database/migrations (применяются при старте или через `app migrate up`).
+
+CREATE TABLE IF NOT EXISTS guests (
+    id SERIAL PRIMARY KEY,
+    token TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    party_size INTEGER NOT NULL DEFAULT 1 CHECK (party_size >= 1),
+    events TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
 CREATE TABLE IF NOT EXISTS wishes (
     id SERIAL PRIMARY KEY,
     name TEXT NOT NULL,
     message TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'approved' CHECK (status IN ('pending', 'approved', 'rejected')),
+    guest_id INTEGER REFERENCES guests(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_wishes_created_id ON wishes(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_wishes_pending ON wishes(created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_wishes_deleted ON wishes(deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS rsvps (
+    id SERIAL PRIMARY KEY,
+    token TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    attending TEXT NOT NULL CHECK (attending IN ('yes', 'no', 'maybe')),
+    plus_ones INTEGER NOT NULL DEFAULT 0 CHECK (plus_ones >= 0),
+    dietary TEXT NOT NULL DEFAULT '',
+    needs_transfer BOOLEAN NOT NULL DEFAULT FALSE,
+    guest_id INTEGER UNIQUE REFERENCES guests(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id SERIAL PRIMARY KEY,
+    channel TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    sent_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_due ON outbox(next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS bot_state (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS admins (
+    chat_id BIGINT PRIMARY KEY,
+    role TEXT NOT NULL CHECK (role IN ('viewer', 'moderator', 'owner')),
+    name TEXT NOT NULL DEFAULT '',
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
-CREATE INDEX IF NOT EXISTS idx_wishes_created ON wishes(created_at DESC);
\ No newline at end of file
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT NOT NULL,
+    action TEXT NOT NULL,
+    wish_ids INTEGER[] NOT NULL DEFAULT '{}',
+    before JSONB,
+    after JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_created ON audit_events(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_wish_ids ON audit_events USING GIN (wish_ids);
+
+CREATE TABLE IF NOT EXISTS rate_limits (
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE SEQUENCE IF NOT EXISTS wish_event_id_seq;
@@ -0,0 +1,117 @@
+// backend/internal/admins/registry.go
+package admins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+)
+
+// Registry — администраторы бота из окружения и из БД.
+// CHAT_ID — всегда владелец, ADMINS="123:moderator,456:viewer" — ещё администраторы.
+// Администраторов из окружения нельзя изменить или удалить командой /admin.
+type Registry struct {
+	store store.AdminStore
+	env   []models.Admin
+}
+
+// FromEnv читает CHAT_ID и ADMINS; администраторы из БД подгружаются при каждом запросе
+func FromEnv(s store.AdminStore) (*Registry, error) {
+	r := &Registry{store: s}
+	seen := map[int64]bool{}
+
+	if owner := os.Getenv("CHAT_ID"); owner != "" {
+		id, err := strconv.ParseInt(owner, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("CHAT_ID должен быть числом")
+		}
+		r.env = append(r.env, models.Admin{ChatID: id, Role: models.RoleOwner})
+		seen[id] = true
+	}
+
+	for _, item := range strings.Split(os.Getenv("ADMINS"), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		idStr, role, _ := strings.Cut(item, ":")
+		id, err := strconv.ParseInt(strings.TrimSpace(idStr), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("ADMINS: некорректный chat ID в %q", item)
+		}
+		role = strings.TrimSpace(role)
+		if role == "" {
+			role = models.RoleViewer
+		}
+		if !models.ValidRole(role) {
+			return nil, fmt.Errorf("ADMINS: неизвестная роль %q", role)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		r.env = append(r.env, models.Admin{ChatID: id, Role: role})
+	}
+	return r, nil
+}
+
+// Static сообщает, задан ли администратор в окружении
+func (r *Registry) Static(chatID int64) bool {
+	for _, a := range r.env {
+		if a.ChatID == chatID {
+			return true
+		}
+	}
+	return false
+}
+
+// Role возвращает роль пользователя или пустую строку, если доступа нет
+func (r *Registry) Role(ctx context.Context, chatID int64) (string, error) {
+	for _, a := range r.env {
+		if a.ChatID == chatID {
+			return a.Role, nil
+		}
+	}
+	a, err := r.store.GetAdmin(ctx, chatID)
+	if errors.Is(err, store.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return a.Role, nil
+}
+
+// List возвращает всех администраторов: сначала из окружения, затем из БД
+func (r *Registry) List(ctx context.Context) ([]models.Admin, error) {
+	stored, err := r.store.ListAdmins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	admins := append([]models.Admin{}, r.env...)
+	for _, a := range stored {
+		if !r.Static(a.ChatID) {
+			admins = append(admins, a)
+		}
+	}
+	return admins, nil
+}
+
+// ChatIDs — кому рассылать уведомления: всем администраторам
+func (r *Registry) ChatIDs(ctx context.Context) ([]int64, error) {
+	admins, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(admins))
+	for i, a := range admins {
+		ids[i] = a.ChatID
+	}
+	return ids, nil
+}
@@ -0,0 +1,208 @@
+// backend/internal/backup/backup.go
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"wedding-backend/internal/store"
+)
+
+// FormatVersion — версия формата архива в манифесте
+const FormatVersion = 1
+
+// Файлы внутри архива; wishes.json в том же формате, что и вложение /list
+const (
+	ManifestFile = "manifest.json"
+	WishesFile   = "wishes.json"
+	GuestsFile   = "guests.json"
+	RSVPsFile    = "rsvps.json"
+	AdminsFile   = "admins.json"
+	AuditFile    = "audit.json"
+)
+
+// Пределы распаковки: архив приходит из чата, и в нём может оказаться что угодно
+const (
+	// maxFileSize — предел размера одного файла
+	maxFileSize = 20 << 20
+	// maxFiles — предел числа файлов (в настоящем архиве их шесть)
+	maxFiles = 16
+	// maxTotalSize — предел суммарного размера распакованных файлов
+	maxTotalSize = 64 << 20
+)
+
+// ErrCorrupt — архив повреждён или не совпадает с манифестом
+var ErrCorrupt = errors.New("архив повреждён")
+
+// Manifest описывает содержимое архива
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	// Counts — число записей в каждом файле
+	Counts map[string]int `json:"counts"`
+	// Checksums — SHA-256 каждого файла
+	Checksums map[string]string `json:"checksums"`
+}
+
+// Archive — распакованная резервная копия
+type Archive struct {
+	Manifest Manifest
+	Files    map[string][]byte
+}
+
+// Snapshot выгружает все таблицы с данными гостей и сжимает их в tar.gz с манифестом
+func Snapshot(ctx context.Context, s store.Store, now time.Time) ([]byte, Manifest, error) {
+	wishes, err := s.List(ctx, store.ListFilter{Trash: store.TrashInclude})
+	if err != nil {
+		return nil, Manifest{}, fmt.Errorf("пожелания: %w", err)
+	}
+	guests, err := s.ListGuests(ctx)
+	if err != nil {
+		return nil, Manifest{}, fmt.Errorf("гости: %w", err)
+	}
+	rsvps, err := s.ListRSVPs(ctx)
+	if err != nil {
+		return nil, Manifest{}, fmt.Errorf("ответы: %w", err)
+	}
+	admins, err := s.ListAdmins(ctx)
+	if err != nil {
+		return nil, Manifest{}, fmt.Errorf("администраторы: %w", err)
+	}
+	audit, err := s.ListAudit(ctx, store.AuditFilter{})
+	if err != nil {
+		return nil, Manifest{}, fmt.Errorf("журнал: %w", err)
+	}
+
+	manifest := Manifest{
+		Version:   FormatVersion,
+		CreatedAt: now.UTC(),
+		Counts: map[string]int{
+			WishesFile: len(wishes),
+			GuestsFile: len(guests),
+			RSVPsFile:  len(rsvps),
+			AdminsFile: len(admins),
+			AuditFile:  len(audit),
+		},
+		Checksums: make(map[string]string),
+	}
+	files := []struct {
+		name string
+		data any
+	}{
+		{WishesFile, wishes},
+		{GuestsFile, guests},
+		{RSVPsFile, rsvps},
+		{AdminsFile, admins},
+		{AuditFile, audit},
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range files {
+		data, err := json.MarshalIndent(f.data, "", "  ")
+		if err != nil {
+			return nil, Manifest{}, err
+		}
+		manifest.Checksums[f.name] = Checksum(data)
+		if err := writeFile(tw, f.name, data, manifest.CreatedAt); err != nil {
+			return nil, Manifest{}, err
+		}
+	}
+	// Манифест последним: в нём уже все контрольные суммы
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, Manifest{}, err
+	}
+	if err := writeFile(tw, ManifestFile, data, manifest.CreatedAt); err != nil {
+		return nil, Manifest{}, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, Manifest{}, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, Manifest{}, err
+	}
+	return buf.Bytes(), manifest, nil
+}
+
+func writeFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(data)), ModTime: modTime}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// Open распаковывает архив и сверяет каждый файл с контрольной суммой из манифеста
+func Open(data []byte) (Archive, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return Archive{}, fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	defer gz.Close()
+
+	files := make(map[string][]byte)
+	var total int64
+	tr := tar.NewReader(gz)
+	for count := 1; ; count++ {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Archive{}, fmt.Errorf("%w: %v", ErrCorrupt, err)
+		}
+		if count > maxFiles {
+			return Archive{}, fmt.Errorf("%w: больше %d файлов", ErrCorrupt, maxFiles)
+		}
+		if hdr.Size > maxFileSize {
+			return Archive{}, fmt.Errorf("%w: %s слишком большой", ErrCorrupt, hdr.Name)
+		}
+		if total += hdr.Size; total > maxTotalSize {
+			return Archive{}, fmt.Errorf("%w: распакованный архив больше %d МБ", ErrCorrupt, maxTotalSize>>20)
+		}
+		body, err := io.ReadAll(io.LimitReader(tr, maxFileSize))
+		if err != nil {
+			return Archive{}, fmt.Errorf("%w: %v", ErrCorrupt, err)
+		}
+		files[hdr.Name] = body
+	}
+
+	var manifest Manifest
+	raw, ok := files[ManifestFile]
+	if !ok {
+		return Archive{}, fmt.Errorf("%w: нет %s", ErrCorrupt, ManifestFile)
+	}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return Archive{}, fmt.Errorf("%w: %s: %v", ErrCorrupt, ManifestFile, err)
+	}
+	if manifest.Version > FormatVersion {
+		return Archive{}, fmt.Errorf("%w: неизвестная версия формата %d", ErrCorrupt, manifest.Version)
+	}
+	for name, sum := range manifest.Checksums {
+		body, ok := files[name]
+		if !ok {
+			return Archive{}, fmt.Errorf("%w: нет %s", ErrCorrupt, name)
+		}
+		if Checksum(body) != sum {
+			return Archive{}, fmt.Errorf("%w: контрольная сумма %s не совпадает", ErrCorrupt, name)
+		}
+	}
+	return Archive{Manifest: manifest, Files: files}, nil
+}
+
+// Checksum — SHA-256 в hex
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
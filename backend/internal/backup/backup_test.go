@@ -0,0 +1,89 @@
+// backend/internal/backup/backup_test.go
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func TestSnapshotOpen(t *testing.T) {
+	s := store.NewMemory()
+	ctx := context.Background()
+	if err := s.Create(ctx, &models.Wish{Name: "Аня", Message: "Счастья"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	data, manifest, err := Snapshot(ctx, s, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive, err := Open(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archive.Manifest.Counts[WishesFile] != 1 || manifest.Checksums[WishesFile] != Checksum(archive.Files[WishesFile]) {
+		t.Errorf("манифест %+v", archive.Manifest)
+	}
+}
+
+func TestOpenTooManyFiles(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for i := range maxFiles + 1 {
+		if err := writeFile(tw, fmt.Sprintf("file-%d", i), []byte("{}"), time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+
+	if _, err := Open(buf.Bytes()); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("архив из %d файлов: %v", maxFiles+1, err)
+	}
+}
+
+func TestScheduledOnce(t *testing.T) {
+	s := store.NewMemory()
+	ctx := context.Background()
+	m := New(s, "", 0)
+	var delivered int
+	deliver := func(ctx context.Context, b Backup) { delivered++ }
+
+	// Пока один экземпляр снимает копию, второй блокировку не получает
+	ran, err := s.WithBackupLock(ctx, func(ctx context.Context) error {
+		ok, err := s.WithBackupLock(ctx, func(ctx context.Context) error { return nil })
+		if ok || err != nil {
+			t.Errorf("блокировка взята дважды: %t, %v", ok, err)
+		}
+		return m.scheduled(ctx, time.Hour, deliver)
+	})
+	if !ran || err != nil {
+		t.Fatalf("копия не снята: %t, %v", ran, err)
+	}
+
+	// Следующий экземпляр, дождавшись блокировки, видит свежую копию и не снимает свою
+	if err := m.scheduled(ctx, time.Hour, deliver); err != nil {
+		t.Fatal(err)
+	}
+	if delivered != 1 {
+		t.Errorf("копий отправлено: %d", delivered)
+	}
+}
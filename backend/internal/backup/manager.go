@@ -0,0 +1,248 @@
+// backend/internal/backup/manager.go
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"wedding-backend/internal/store"
+)
+
+const (
+	// DefaultKeep — сколько архивов хранить в каталоге по умолчанию
+	DefaultKeep = 7
+	// retryDelay — пауза после неудачной резервной копии
+	retryDelay = 10 * time.Minute
+	// lockedDelay — пауза, если копию в это время снимает другой экземпляр
+	lockedDelay = time.Minute
+
+	namePrefix = "backup-"
+	nameSuffix = ".tar.gz"
+	nameLayout = "20060102-150405"
+)
+
+// ErrNotFound — архива с таким именем нет в каталоге
+var ErrNotFound = errors.New("архив не найден")
+
+// Backup — только что снятая резервная копия
+type Backup struct {
+	Name     string
+	Data     []byte
+	Manifest Manifest
+	// Checksum — SHA-256 всего архива, чтобы сверить файл, скачанный из Telegram
+	Checksum string
+}
+
+// Info — архив в каталоге
+type Info struct {
+	Name      string
+	Size      int64
+	CreatedAt time.Time
+}
+
+// Manager снимает резервные копии по расписанию и хранит последние Keep в каталоге Dir
+type Manager struct {
+	store store.Store
+	// Dir — каталог для архивов; пусто — архивы только отправляются в Telegram
+	Dir  string
+	Keep int
+}
+
+// New создаёт менеджер; keep <= 0 — DefaultKeep
+func New(s store.Store, dir string, keep int) *Manager {
+	if keep <= 0 {
+		keep = DefaultKeep
+	}
+	return &Manager{store: s, Dir: dir, Keep: keep}
+}
+
+// IsArchiveName проверяет, что имя похоже на архив, созданный менеджером
+func IsArchiveName(name string) bool {
+	return strings.HasPrefix(name, namePrefix) && strings.HasSuffix(name, nameSuffix) &&
+		filepath.Base(name) == name
+}
+
+// Create снимает резервную копию, сохраняет её в каталог и удаляет старые
+func (m *Manager) Create(ctx context.Context) (Backup, error) {
+	now := time.Now()
+	data, manifest, err := Snapshot(ctx, m.store, now)
+	if err != nil {
+		return Backup{}, err
+	}
+	b := Backup{
+		Name:     namePrefix + now.UTC().Format(nameLayout) + nameSuffix,
+		Data:     data,
+		Manifest: manifest,
+		Checksum: Checksum(data),
+	}
+
+	if m.Dir != "" {
+		if err := m.save(b); err != nil {
+			return b, err
+		}
+		if err := m.rotate(); err != nil {
+			log.Printf("⚠️ Не удалось удалить старые резервные копии: %v", err)
+		}
+	}
+	return b, nil
+}
+
+// save пишет архив через временный файл, чтобы в каталоге не оставались недописанные копии
+func (m *Manager) save(b Backup) error {
+	if err := os.MkdirAll(m.Dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(m.Dir, ".backup-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b.Data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(m.Dir, b.Name))
+}
+
+// rotate оставляет в каталоге Keep последних архивов
+func (m *Manager) rotate() error {
+	list, err := m.List()
+	if err != nil {
+		return err
+	}
+	for _, info := range list[min(m.Keep, len(list)):] {
+		if err := os.Remove(filepath.Join(m.Dir, info.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List возвращает архивы из каталога, новые первыми
+func (m *Manager) List() ([]Info, error) {
+	if m.Dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(m.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []Info
+	for _, e := range entries {
+		if e.IsDir() || !IsArchiveName(e.Name()) {
+			continue
+		}
+		created, err := time.Parse(nameLayout, strings.TrimSuffix(strings.TrimPrefix(e.Name(), namePrefix), nameSuffix))
+		if err != nil {
+			continue
+		}
+		fi, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, Info{Name: e.Name(), Size: fi.Size(), CreatedAt: created})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.After(list[j].CreatedAt) })
+	return list, nil
+}
+
+// Load читает архив из каталога и проверяет его
+func (m *Manager) Load(name string) (Archive, error) {
+	if m.Dir == "" || !IsArchiveName(name) {
+		return Archive{}, ErrNotFound
+	}
+	data, err := os.ReadFile(filepath.Join(m.Dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return Archive{}, ErrNotFound
+	}
+	if err != nil {
+		return Archive{}, err
+	}
+	return Open(data)
+}
+
+// Run снимает копию раз в interval и передаёт её deliver, пока не отменён ctx.
+// Время последней копии хранится в БД, поэтому частые перезапуски не сдвигают расписание,
+// а advisory-блокировка не даёт нескольким экземплярам снять одну копию каждый.
+func (m *Manager) Run(ctx context.Context, interval time.Duration, deliver func(ctx context.Context, b Backup)) {
+	for {
+		wait := retryDelay
+		last, err := m.store.LastBackupAt(ctx)
+		if err != nil {
+			log.Printf("❌ Ошибка чтения времени резервной копии: %v", err)
+		} else {
+			wait = time.Until(last.Add(interval))
+		}
+
+		if !sleep(ctx, wait) {
+			return
+		}
+		if err != nil {
+			continue
+		}
+
+		ran, err := m.store.WithBackupLock(ctx, func(ctx context.Context) error {
+			return m.scheduled(ctx, interval, deliver)
+		})
+		switch {
+		case err != nil:
+			log.Printf("❌ Ошибка резервного копирования, повтор через %s: %v", retryDelay, err)
+			wait = retryDelay
+		case !ran:
+			// Копию снимает другой экземпляр: ждём, пока он запишет её время
+			wait = lockedDelay
+		default:
+			continue
+		}
+		if !sleep(ctx, wait) {
+			return
+		}
+	}
+}
+
+// scheduled снимает копию, если её ещё не снял другой экземпляр, пока мы ждали блокировку
+func (m *Manager) scheduled(ctx context.Context, interval time.Duration, deliver func(ctx context.Context, b Backup)) error {
+	last, err := m.store.LastBackupAt(ctx)
+	if err != nil {
+		return fmt.Errorf("время копии: %w", err)
+	}
+	if time.Until(last.Add(interval)) > 0 {
+		return nil
+	}
+
+	b, err := m.Create(ctx)
+	if err != nil {
+		return err
+	}
+	if err := m.store.SaveLastBackupAt(ctx, b.Manifest.CreatedAt); err != nil {
+		return fmt.Errorf("время копии: %w", err)
+	}
+	log.Printf("💾 Резервная копия %s (%d байт)", b.Name, len(b.Data))
+	deliver(ctx, b)
+	return nil
+}
+
+// sleep ждёт d; false — ctx отменён раньше
+func sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(max(d, 0)):
+		return true
+	}
+}
@@ -0,0 +1,202 @@
+// backend/internal/challenge/challenge.go
+package challenge
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Причины, по которым форма не прошла проверку
+var (
+	ErrInvalid  = errors.New("неверный токен формы")
+	ErrTooFast  = errors.New("форма заполнена слишком быстро")
+	ErrExpired  = errors.New("токен формы устарел")
+	ErrNoProof  = errors.New("нет решения задачи")
+	ErrReplayed = errors.New("токен формы уже использован")
+)
+
+const (
+	// DefaultMinFill — быстрее человек пожелание не напишет
+	DefaultMinFill = 3 * time.Second
+	// DefaultTTL — сколько живёт токен: страницу приглашения могут держать открытой долго
+	DefaultTTL = 2 * time.Hour
+	// MaxDifficulty — больше браузер на телефоне будет считать слишком долго
+	MaxDifficulty = 24
+)
+
+// Challenge — выданный форме токен и задача
+type Challenge struct {
+	Token string `json:"token"`
+	// Difficulty — сколько ведущих нулевых бит должно быть у SHA-256(token + ":" + solution); 0 — без задачи
+	Difficulty int       `json:"difficulty"`
+	MinFillMs  int64     `json:"min_fill_ms"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// Issuer выдаёт и проверяет подписанные HMAC токены формы. Токен — «время выдачи.сложность.случайное.подпись»:
+// сервер ничего не хранит до отправки формы, поэтому токены работают на любом экземпляре с тем же секретом.
+type Issuer struct {
+	secret     []byte
+	MinFill    time.Duration
+	TTL        time.Duration
+	Difficulty int
+
+	// used — подписи уже принятых токенов до истечения их срока (в памяти экземпляра)
+	mu   sync.Mutex
+	used map[string]time.Time
+}
+
+// New создаёт выдающего токены; пустой secret заменяется случайным (токены переживут только этот процесс)
+func New(secret []byte, minFill, ttl time.Duration, difficulty int) *Issuer {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic(err) // crypto/rand не должен отказывать
+		}
+	}
+	return &Issuer{
+		secret:     secret,
+		MinFill:    minFill,
+		TTL:        ttl,
+		Difficulty: min(max(difficulty, 0), MaxDifficulty),
+		used:       make(map[string]time.Time),
+	}
+}
+
+// Issue выдаёт новый токен
+func (i *Issuer) Issue(now time.Time) Challenge {
+	nonce := make([]byte, 12)
+	if _, err := rand.Read(nonce); err != nil {
+		panic(err)
+	}
+	payload := strconv.FormatInt(now.UnixMilli(), 10) + "." + strconv.Itoa(i.Difficulty) + "." +
+		base64.RawURLEncoding.EncodeToString(nonce)
+	return Challenge{
+		Token:      payload + "." + i.sign(payload),
+		Difficulty: i.Difficulty,
+		MinFillMs:  i.MinFill.Milliseconds(),
+		ExpiresAt:  now.Add(i.TTL).UTC(),
+	}
+}
+
+// Check проверяет подпись, время заполнения, срок и решение задачи, но не тратит токен:
+// если пожелание потом отклонят, гость отправит его ещё раз с тем же токеном.
+func (i *Issuer) Check(token, solution string, now time.Time) error {
+	sig, issued, difficulty, err := i.open(token)
+	if err != nil {
+		return err
+	}
+	age := now.Sub(issued)
+	if age < i.MinFill {
+		return ErrTooFast
+	}
+	if age > i.TTL {
+		return ErrExpired
+	}
+	// Сложность берётся из подписанного токена: после её смены уже выданные токены остаются в силе
+	if !Solved(token, solution, difficulty) {
+		return ErrNoProof
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if _, ok := i.used[sig]; ok {
+		return ErrReplayed
+	}
+	return nil
+}
+
+// Consume отмечает проверенный токен использованным; второй раз он не пройдёт.
+// Из двух одновременных запросов с одним токеном ErrReplayed получит второй.
+func (i *Issuer) Consume(token string, now time.Time) error {
+	sig, issued, _, err := i.open(token)
+	if err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for s, expires := range i.used {
+		if now.After(expires) {
+			delete(i.used, s)
+		}
+	}
+	if _, ok := i.used[sig]; ok {
+		return ErrReplayed
+	}
+	i.used[sig] = issued.Add(i.TTL)
+	return nil
+}
+
+// Release возвращает токен, потраченный Consume: пожелание с ним так и не сохранилось
+func (i *Issuer) Release(token string) {
+	sig, _, _, err := i.open(token)
+	if err != nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.used, sig)
+}
+
+// open проверяет подпись и разбирает токен
+func (i *Issuer) open(token string) (sig string, issued time.Time, difficulty int, err error) {
+	payload, sig, ok := cutLast(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(i.sign(payload))) {
+		return "", time.Time{}, 0, ErrInvalid
+	}
+	parts := strings.Split(payload, ".")
+	if len(parts) != 3 {
+		return "", time.Time{}, 0, ErrInvalid
+	}
+	issuedMs, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", time.Time{}, 0, ErrInvalid
+	}
+	difficulty, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return "", time.Time{}, 0, ErrInvalid
+	}
+	return sig, time.UnixMilli(issuedMs), difficulty, nil
+}
+
+// Solved проверяет, что у SHA-256(token + ":" + solution) не меньше difficulty ведущих нулевых бит
+func Solved(token, solution string, difficulty int) bool {
+	if difficulty <= 0 {
+		return true
+	}
+	if solution == "" || len(solution) > 32 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(token + ":" + solution))
+	zeros := 0
+	for _, b := range sum {
+		if b != 0 {
+			zeros += bits.LeadingZeros8(b)
+			break
+		}
+		zeros += 8
+	}
+	return zeros >= difficulty
+}
+
+func (i *Issuer) sign(payload string) string {
+	mac := hmac.New(sha256.New, i.secret)
+	mac.Write([]byte("wish-form:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func cutLast(s, sep string) (before, after string, ok bool) {
+	if n := strings.LastIndex(s, sep); n >= 0 {
+		return s[:n], s[n+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,90 @@
+// backend/internal/challenge/challenge_test.go
+package challenge
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	i := New([]byte("secret"), 3*time.Second, time.Hour, 0)
+	now := time.Now()
+	c := i.Issue(now)
+
+	tests := []struct {
+		token string
+		at    time.Time
+		want  error
+	}{
+		{c.Token, now.Add(time.Second), ErrTooFast},
+		{c.Token, now.Add(2 * time.Hour), ErrExpired},
+		{c.Token + "x", now.Add(5 * time.Second), ErrInvalid},
+		{"мусор", now.Add(5 * time.Second), ErrInvalid},
+		{New([]byte("other"), 0, time.Hour, 0).Issue(now).Token, now.Add(5 * time.Second), ErrInvalid},
+		{c.Token, now.Add(5 * time.Second), nil},
+	}
+	for _, tt := range tests {
+		if err := i.Check(tt.token, "", tt.at); !errors.Is(err, tt.want) {
+			t.Errorf("Check(%q) = %v, хотели %v", tt.token, err, tt.want)
+		}
+	}
+}
+
+func TestConsume(t *testing.T) {
+	i := New(nil, 0, time.Hour, 0)
+	now := time.Now()
+	token := i.Issue(now).Token
+
+	// Проверка без траты токена повторяется сколько угодно
+	for range 2 {
+		if err := i.Check(token, "", now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := i.Consume(token, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := i.Check(token, "", now); !errors.Is(err, ErrReplayed) {
+		t.Errorf("Check после Consume: %v", err)
+	}
+	if err := i.Consume(token, now); !errors.Is(err, ErrReplayed) {
+		t.Errorf("повторный Consume: %v", err)
+	}
+	if err := i.Consume("мусор", now); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Consume неподписанного токена: %v", err)
+	}
+
+	// Пожелание не сохранилось — токен снова годен
+	i.Release(token)
+	if err := i.Consume(token, now); err != nil {
+		t.Errorf("Consume после Release: %v", err)
+	}
+}
+
+func TestSolved(t *testing.T) {
+	const difficulty = 8
+	i := New(nil, 0, time.Hour, difficulty)
+	now := time.Now()
+	c := i.Issue(now)
+
+	if err := i.Check(c.Token, "", now); !errors.Is(err, ErrNoProof) {
+		t.Errorf("без решения: %v", err)
+	}
+	solution := ""
+	for n := 0; solution == ""; n++ {
+		if Solved(c.Token, strconv.Itoa(n), difficulty) {
+			solution = strconv.Itoa(n)
+		}
+	}
+	if err := i.Check(c.Token, solution, now); err != nil {
+		t.Errorf("с решением %s: %v", solution, err)
+	}
+
+	// Сложность берётся из токена: смена настройки не ломает выданные токены
+	i.Difficulty = MaxDifficulty
+	if err := i.Check(c.Token, solution, now); err != nil {
+		t.Errorf("после смены сложности: %v", err)
+	}
+}
@@ -2,6 +2,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"os"
@@ -38,19 +39,12 @@ func Close() {
 	}
 }
 
+// Migrate применяет недостающие миграции при старте и останавливает сервер,
+// если схема БД новее, чем этот бинарник
 func Migrate() {
-	query := `
-	CREATE TABLE IF NOT EXISTS wishes (
-		id SERIAL PRIMARY KEY,
-		name TEXT NOT NULL,
-		message TEXT NOT NULL,
-		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-	);
-	CREATE INDEX IF NOT EXISTS idx_wishes_created ON wishes(created_at DESC);
-	`
-	_, err := DB.Exec(query)
+	n, err := MigrateUp(context.Background(), DB)
 	if err != nil {
-		log.Fatal("❌ Ошибка миграции:", err)
+		log.Fatal("❌ Ошибка миграции: ", err)
 	}
-	log.Println("✅ Таблица wishes готова")
+	log.Printf("✅ Схема БД актуальна (применено миграций: %d)", n)
 }
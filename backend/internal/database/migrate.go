@@ -0,0 +1,286 @@
+// backend/internal/database/migrate.go
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID — ключ advisory-блокировки, чтобы два экземпляра не мигрировали одновременно
+const migrationLockID = 0x77656464 // "wedd"
+
+// Migration — одна версия схемы: пара файлов NNNN_name.up.sql / NNNN_name.down.sql
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus — состояние миграции в конкретной БД
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// ErrDatabaseAhead — в БД применены миграции, о которых этот бинарник не знает
+type ErrDatabaseAhead struct {
+	DBVersion     int
+	BinaryVersion int
+}
+
+func (e *ErrDatabaseAhead) Error() string {
+	return fmt.Sprintf("версия схемы БД (%d) новее, чем знает приложение (%d)", e.DBVersion, e.BinaryVersion)
+}
+
+// loadMigrations читает встроенные SQL-файлы и сортирует их по версии
+func loadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, e := range entries {
+		name := e.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("неизвестный файл миграции: %s", name)
+		}
+
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		versionStr, title, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("имя миграции должно быть вида NNNN_name: %s", name)
+		}
+		version, err := strconv.Atoi(versionStr)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("некорректная версия миграции: %s", name)
+		}
+
+		body, err := migrationFiles.ReadFile(path.Join("migrations", name))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: title}
+			byVersion[version] = m
+		} else if m.Name != title {
+			return nil, fmt.Errorf("у версии %d разные имена: %s и %s", version, m.Name, title)
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("у миграции %04d нет up-скрипта", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// withMigrationLock выполняет fn на отдельном соединении под advisory-блокировкой
+func withMigrationLock(ctx context.Context, db *sql.DB, fn func(conn *sql.Conn) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	if _, err := conn.ExecContext(ctx, `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+	)`); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// queryer — *sql.Conn под блокировкой или *sql.DB для чтения без неё
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// appliedVersions возвращает время применения каждой версии из schema_migrations
+func appliedVersions(ctx context.Context, conn queryer) (map[int]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var v int
+		var at time.Time
+		if err := rows.Scan(&v, &at); err != nil {
+			return nil, err
+		}
+		applied[v] = at
+	}
+	return applied, rows.Err()
+}
+
+// checkAhead возвращает ErrDatabaseAhead, если в БД есть неизвестные версии
+func checkAhead(migrations []Migration, applied map[int]time.Time) error {
+	latest := 0
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].Version
+	}
+	for v := range applied {
+		if v > latest {
+			return &ErrDatabaseAhead{DBVersion: v, BinaryVersion: latest}
+		}
+	}
+	return nil
+}
+
+// runInTx выполняет скрипт миграции и запись в schema_migrations одной транзакцией
+func runInTx(ctx context.Context, conn *sql.Conn, script, bookkeeping string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if strings.TrimSpace(script) != "" {
+		if _, err := tx.ExecContext(ctx, script); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// MigrateUp применяет все ещё не применённые миграции и возвращает их количество
+func MigrateUp(ctx context.Context, db *sql.DB) (int, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = withMigrationLock(ctx, db, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := checkAhead(migrations, applied); err != nil {
+			return err
+		}
+
+		for _, m := range migrations {
+			if _, ok := applied[m.Version]; ok {
+				continue
+			}
+			err := runInTx(ctx, conn, m.Up,
+				"INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", m.Version, m.Name)
+			if err != nil {
+				return fmt.Errorf("миграция %04d_%s: %w", m.Version, m.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// MigrateDown откатывает steps последних применённых миграций и возвращает их количество
+func MigrateDown(ctx context.Context, db *sql.DB, steps int) (int, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = withMigrationLock(ctx, db, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := checkAhead(migrations, applied); err != nil {
+			return err
+		}
+
+		for i := len(migrations) - 1; i >= 0 && count < steps; i-- {
+			m := migrations[i]
+			if _, ok := applied[m.Version]; !ok {
+				continue
+			}
+			err := runInTx(ctx, conn, m.Down,
+				"DELETE FROM schema_migrations WHERE version = $1", m.Version)
+			if err != nil {
+				return fmt.Errorf("откат %04d_%s: %w", m.Version, m.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status возвращает список известных миграций с отметкой о применении.
+// Только читает: не берёт блокировку и не создаёт schema_migrations, поэтому не ждёт идущую миграцию.
+func Status(ctx context.Context, db *sql.DB) ([]MigrationStatus, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists); err != nil {
+		return nil, err
+	}
+	applied := map[int]time.Time{}
+	if exists {
+		if applied, err = appliedVersions(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+
+	var statuses []MigrationStatus
+	for _, m := range migrations {
+		s := MigrationStatus{Version: m.Version, Name: m.Name}
+		if at, ok := applied[m.Version]; ok {
+			s.AppliedAt = &at
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, checkAhead(migrations, applied)
+}
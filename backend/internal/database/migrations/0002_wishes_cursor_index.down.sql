@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_wishes_created ON wishes(created_at DESC);
+DROP INDEX IF EXISTS idx_wishes_created_id;
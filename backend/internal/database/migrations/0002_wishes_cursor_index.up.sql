@@ -0,0 +1,3 @@
+-- Индекс под курсорную пагинацию по (created_at, id)
+CREATE INDEX IF NOT EXISTS idx_wishes_created_id ON wishes(created_at DESC, id DESC);
+DROP INDEX IF EXISTS idx_wishes_created;
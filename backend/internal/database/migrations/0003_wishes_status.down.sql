@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_wishes_pending;
+ALTER TABLE wishes DROP COLUMN IF EXISTS status;
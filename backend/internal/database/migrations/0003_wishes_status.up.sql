@@ -0,0 +1,6 @@
+-- Статус модерации: существующие пожелания считаются одобренными
+ALTER TABLE wishes
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'approved'
+    CHECK (status IN ('pending', 'approved', 'rejected'));
+
+CREATE INDEX IF NOT EXISTS idx_wishes_pending ON wishes(created_at) WHERE status = 'pending';
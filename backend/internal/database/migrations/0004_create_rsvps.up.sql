@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS rsvps (
+    id SERIAL PRIMARY KEY,
+    token TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    attending TEXT NOT NULL CHECK (attending IN ('yes', 'no', 'maybe')),
+    plus_ones INTEGER NOT NULL DEFAULT 0 CHECK (plus_ones >= 0),
+    dietary TEXT NOT NULL DEFAULT '',
+    needs_transfer BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+ALTER TABLE rsvps DROP COLUMN IF EXISTS guest_id;
+ALTER TABLE wishes DROP COLUMN IF EXISTS guest_id;
+DROP TABLE IF EXISTS guests;
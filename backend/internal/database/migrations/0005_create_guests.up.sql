@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS guests (
+    id SERIAL PRIMARY KEY,
+    token TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    party_size INTEGER NOT NULL DEFAULT 1 CHECK (party_size >= 1),
+    events TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Пожелания и ответы, оставленные по персональной ссылке
+ALTER TABLE wishes ADD COLUMN IF NOT EXISTS guest_id INTEGER REFERENCES guests(id) ON DELETE SET NULL;
+ALTER TABLE rsvps ADD COLUMN IF NOT EXISTS guest_id INTEGER UNIQUE REFERENCES guests(id) ON DELETE SET NULL;
@@ -0,0 +1,7 @@
+-- Администраторы бота, добавленные командой /admin add (владелец из CHAT_ID и ADMINS — в окружении)
+CREATE TABLE IF NOT EXISTS admins (
+    chat_id BIGINT PRIMARY KEY,
+    role TEXT NOT NULL CHECK (role IN ('viewer', 'moderator', 'owner')),
+    name TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_wishes_deleted;
+ALTER TABLE wishes DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,4 @@
+-- Корзина: удалённые пожелания хранятся до очистки по сроку
+ALTER TABLE wishes ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_wishes_deleted ON wishes(deleted_at) WHERE deleted_at IS NOT NULL;
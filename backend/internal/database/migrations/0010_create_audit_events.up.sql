@@ -0,0 +1,13 @@
+-- Журнал действий администраторов: кто, когда и что изменил
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT NOT NULL,
+    action TEXT NOT NULL,
+    wish_ids INTEGER[] NOT NULL DEFAULT '{}',
+    before JSONB,
+    after JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_created ON audit_events(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_wish_ids ON audit_events USING GIN (wish_ids);
@@ -0,0 +1,6 @@
+-- Вёдра ограничителя частоты POST /api/wish, общие для всех экземпляров
+CREATE TABLE IF NOT EXISTS rate_limits (
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1 @@
+DROP SEQUENCE IF EXISTS wish_event_id_seq;
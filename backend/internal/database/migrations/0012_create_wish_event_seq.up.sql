@@ -0,0 +1,2 @@
+-- Общие для всех экземпляров ID событий ленты (Last-Event-ID при LISTEN/NOTIFY)
+CREATE SEQUENCE IF NOT EXISTS wish_event_id_seq;
@@ -0,0 +1,153 @@
+// backend/internal/events/broadcaster.go
+package events
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+)
+
+// Типы событий ленты пожеланий
+const (
+	WishCreated  = "created"
+	WishDeleted  = "deleted"
+	WishRestored = "restored"
+	// Reset — клиенту нужно перечитать ленту целиком (часть событий потеряна)
+	Reset = "reset"
+)
+
+// Event — событие ленты. С LISTEN/NOTIFY ID берётся из последовательности в БД и одинаков
+// на всех экземплярах, без него — из счётчика процесса. 0 — событие без ID:
+// к нему нельзя вернуться по Last-Event-ID.
+type Event struct {
+	ID   uint64
+	Type string
+	Data json.RawMessage
+}
+
+// Relay — внешний транспорт событий (например, Postgres NOTIFY).
+// Если задан, Publish отдаёт событие ему, а доставка подписчикам
+// происходит, когда событие вернётся через Deliver уже с общим ID.
+type Relay interface {
+	Send(eventType string, data json.RawMessage) error
+}
+
+// Subscription — подписка на ленту; канал закрывается при отписке
+// или если подписчик не успевает читать события
+type Subscription struct {
+	C <-chan Event
+	c chan Event
+}
+
+// Broadcaster раздаёт события всем подписчикам и хранит последние
+// события, чтобы переподключившийся клиент мог дочитать пропущенное
+type Broadcaster struct {
+	mu      sync.Mutex
+	nextID  uint64
+	history []Event
+	limit   int
+	subs    map[*Subscription]struct{}
+	relay   Relay
+}
+
+// NewBroadcaster создаёт рассыльщик, хранящий history последних событий
+func NewBroadcaster(history int) *Broadcaster {
+	return &Broadcaster{
+		nextID: 1,
+		limit:  history,
+		subs:   make(map[*Subscription]struct{}),
+	}
+}
+
+// SetRelay включает внешний транспорт событий
+func (b *Broadcaster) SetRelay(r Relay) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.relay = r
+}
+
+// Publish публикует событие; data сериализуется в JSON
+func (b *Broadcaster) Publish(eventType string, data any) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("❌ Ошибка сериализации события %s: %v", eventType, err)
+		return
+	}
+
+	b.mu.Lock()
+	relay := b.relay
+	b.mu.Unlock()
+
+	if relay != nil {
+		err := relay.Send(eventType, raw)
+		if err == nil {
+			return
+		}
+		// Счётчик процесса здесь не годится: его ID совпадут с общими ID других экземпляров
+		log.Printf("⚠️ Не удалось отправить событие через relay, доставляем локально: %v", err)
+		b.Deliver(Event{Type: eventType, Data: raw})
+		return
+	}
+
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.mu.Unlock()
+	b.Deliver(Event{ID: id, Type: eventType, Data: raw})
+}
+
+// Deliver рассылает событие локальным подписчикам и запоминает его в истории
+func (b *Broadcaster) Deliver(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.history = append(b.history, e)
+	if len(b.history) > b.limit {
+		b.history = b.history[len(b.history)-b.limit:]
+	}
+
+	for sub := range b.subs {
+		select {
+		case sub.c <- e:
+		default:
+			// Медленный клиент: отключаем, он переподключится с Last-Event-ID
+			delete(b.subs, sub)
+			close(sub.c)
+		}
+	}
+}
+
+// Subscribe подписывает на новые события. Если lastID > 0, возвращает
+// события, пришедшие после него; complete = false означает, что события lastID
+// нет в истории (вытеснено или выдано до перезапуска) и клиенту нужно перечитать ленту целиком.
+// Событие ищется по позиции, а не сравнением ID: через NOTIFY события приходят
+// на все экземпляры в одном порядке, но их ID не обязаны возрастать.
+func (b *Broadcaster) Subscribe(lastID uint64) (sub *Subscription, missed []Event, complete bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := make(chan Event, 16)
+	sub = &Subscription{C: c, c: c}
+	b.subs[sub] = struct{}{}
+
+	if lastID == 0 {
+		return sub, nil, true
+	}
+	for i, e := range b.history {
+		if e.ID == lastID {
+			return sub, append([]Event(nil), b.history[i+1:]...), true
+		}
+	}
+	return sub, nil, false
+}
+
+// Unsubscribe отменяет подписку
+func (b *Broadcaster) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.c)
+	}
+}
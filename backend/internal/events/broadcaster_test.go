@@ -0,0 +1,87 @@
+// backend/internal/events/broadcaster_test.go
+package events
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// loopRelay возвращает события через Deliver с заданными ID, как NOTIFY с последовательностью из БД
+type loopRelay struct {
+	b   *Broadcaster
+	ids []uint64
+}
+
+func (r *loopRelay) Send(eventType string, data json.RawMessage) error {
+	id := r.ids[0]
+	r.ids = r.ids[1:]
+	r.b.Deliver(Event{ID: id, Type: eventType, Data: data})
+	return nil
+}
+
+func ids(events []Event) []uint64 {
+	var out []uint64
+	for _, e := range events {
+		out = append(out, e.ID)
+	}
+	return out
+}
+
+func TestSubscribeResumesAfterLastID(t *testing.T) {
+	b := NewBroadcaster(10)
+	for i := 0; i < 3; i++ {
+		b.Publish(WishCreated, i)
+	}
+
+	_, missed, complete := b.Subscribe(1)
+	if !complete || len(missed) != 2 || missed[0].ID != 2 || missed[1].ID != 3 {
+		t.Errorf("после 1: %v, complete=%t", ids(missed), complete)
+	}
+	_, missed, complete = b.Subscribe(3)
+	if !complete || len(missed) != 0 {
+		t.Errorf("после последнего: %v, complete=%t", ids(missed), complete)
+	}
+	// ID из другого процесса или до перезапуска
+	if _, _, complete = b.Subscribe(42); complete {
+		t.Error("неизвестный ID должен требовать перечитать ленту")
+	}
+}
+
+func TestSubscribeHistoryEvicted(t *testing.T) {
+	b := NewBroadcaster(2)
+	for i := 0; i < 4; i++ {
+		b.Publish(WishCreated, i)
+	}
+	if _, _, complete := b.Subscribe(1); complete {
+		t.Error("вытесненное событие должно требовать перечитать ленту")
+	}
+}
+
+func TestSubscribeRelayIDs(t *testing.T) {
+	// Другой экземпляр взял из последовательности 101, этот — 100, а NOTIFY доставил их в порядке коммита
+	b := NewBroadcaster(10)
+	b.SetRelay(&loopRelay{b: b, ids: []uint64{101, 100, 102}})
+	for i := 0; i < 3; i++ {
+		b.Publish(WishCreated, i)
+	}
+
+	_, missed, complete := b.Subscribe(101)
+	if got := ids(missed); !complete || len(got) != 2 || got[0] != 100 || got[1] != 102 {
+		t.Errorf("после 101: %v, complete=%t", got, complete)
+	}
+}
+
+func TestSubscribeLive(t *testing.T) {
+	b := NewBroadcaster(10)
+	sub, _, _ := b.Subscribe(0)
+	b.Publish(WishDeleted, map[string]int{"id": 5})
+
+	e := <-sub.C
+	if e.Type != WishDeleted || string(e.Data) != `{"id":5}` {
+		t.Errorf("получили %+v", e)
+	}
+	b.Unsubscribe(sub)
+	if _, ok := <-sub.C; ok {
+		t.Error("канал не закрыт после отписки")
+	}
+}
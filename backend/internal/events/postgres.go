@@ -0,0 +1,76 @@
+// backend/internal/events/postgres.go
+package events
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// notifyChannel — канал LISTEN/NOTIFY для событий ленты
+const notifyChannel = "wish_events"
+
+// notification — формат payload в pg_notify
+type notification struct {
+	ID   uint64          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// PostgresRelay рассылает события через NOTIFY, чтобы их получили все экземпляры
+type PostgresRelay struct {
+	db *sql.DB
+}
+
+// Send берёт ID события из последовательности wish_event_id_seq, чтобы он был одинаковым на всех экземплярах
+func (r *PostgresRelay) Send(eventType string, data json.RawMessage) error {
+	var id uint64
+	if err := r.db.QueryRow("SELECT nextval('wish_event_id_seq')").Scan(&id); err != nil {
+		return err
+	}
+	payload, err := json.Marshal(notification{ID: id, Type: eventType, Data: data})
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec("SELECT pg_notify($1, $2)", notifyChannel, string(payload))
+	return err
+}
+
+// ListenPostgres подписывается на канал событий и включает PostgresRelay у b.
+// Полученные уведомления (в том числе свои) доставляются подписчикам b.
+func ListenPostgres(connStr string, db *sql.DB, b *Broadcaster) error {
+	listener := pq.NewListener(connStr, 10*time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("⚠️ LISTEN %s: %v", notifyChannel, err)
+		}
+	})
+	if err := listener.Listen(notifyChannel); err != nil {
+		listener.Close()
+		return err
+	}
+
+	b.SetRelay(&PostgresRelay{db: db})
+
+	go func() {
+		for n := range listener.Notify {
+			if n == nil {
+				// Соединение переустановлено — уведомления за это время потеряны
+				b.Deliver(Event{Type: Reset, Data: json.RawMessage("{}")})
+				continue
+			}
+
+			var msg notification
+			if err := json.Unmarshal([]byte(n.Extra), &msg); err != nil {
+				log.Printf("❌ Некорректное уведомление %s: %v", notifyChannel, err)
+				continue
+			}
+			b.Deliver(Event{ID: msg.ID, Type: msg.Type, Data: msg.Data})
+		}
+	}()
+
+	log.Printf("✅ События ленты синхронизируются через LISTEN %s", notifyChannel)
+	return nil
+}
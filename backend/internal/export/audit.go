@@ -0,0 +1,58 @@
+// backend/internal/export/audit.go
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+// AuditFormats — форматы выгрузки журнала аудита
+var AuditFormats = []string{"csv", "json"}
+
+// RenderAudit выгружает журнал аудита: CSV для таблиц, JSON — со снимками как есть
+func RenderAudit(format string, events []models.AuditEvent, opts Options) (File, error) {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+
+	switch format {
+	case "csv":
+		data, err := renderAuditCSV(events, opts)
+		return File{Name: "audit.csv", ContentType: "text/csv; charset=utf-8", Data: data}, err
+	case "json":
+		data, err := json.MarshalIndent(events, "", "  ")
+		return File{Name: "audit.json", ContentType: "application/json", Data: data}, err
+	}
+	return File{}, ErrUnknownFormat
+}
+
+func renderAuditCSV(events []models.AuditEvent, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(utf8BOM)
+	out := csv.NewWriter(&buf)
+	out.UseCRLF = true
+	out.Write([]string{"id", "created_at", "actor_id", "action", "wish_ids", "before", "after"})
+	for _, e := range events {
+		ids := make([]string, len(e.WishIDs))
+		for i, id := range e.WishIDs {
+			ids[i] = strconv.Itoa(id)
+		}
+		out.Write([]string{
+			strconv.FormatInt(e.ID, 10),
+			e.CreatedAt.In(opts.Location).Format("2006-01-02 15:04:05"),
+			strconv.FormatInt(e.ActorID, 10),
+			e.Action,
+			strings.Join(ids, " "),
+			string(e.Before),
+			string(e.After),
+		})
+	}
+	out.Flush()
+	return buf.Bytes(), out.Error()
+}
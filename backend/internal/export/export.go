@@ -0,0 +1,165 @@
+// backend/internal/export/export.go
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+// Formats — поддерживаемые форматы выгрузки в порядке показа в справке
+var Formats = []string{"csv", "json", "html", "md"}
+
+// ErrUnknownFormat — формат не из Formats
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// utf8BOM — с ним Excel правильно открывает кириллицу в CSV
+const utf8BOM = "\ufeff"
+
+// Options — оформление выгрузки
+type Options struct {
+	// Location — часовой пояс свадьбы для дат
+	Location *time.Location
+	// Title — заголовок печатной книги пожеланий
+	Title string
+}
+
+// File — готовый файл выгрузки
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Public сообщает, попадает ли формат в «книгу для родных»: HTML и Markdown
+// содержат только одобренные пожелания, CSV и JSON — все (это резервные копии)
+func Public(format string) bool {
+	return format == "html" || format == "md"
+}
+
+// Render собирает выгрузку пожеланий в формате format
+func Render(format string, wishes []models.Wish, opts Options) (File, error) {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	if opts.Title == "" {
+		opts.Title = "Книга пожеланий"
+	}
+
+	switch format {
+	case "csv":
+		data, err := renderCSV(wishes, opts)
+		return File{Name: "wishes.csv", ContentType: "text/csv; charset=utf-8", Data: data}, err
+	case "json":
+		// Тот же формат, что понимает восстановление из wishes.json
+		data, err := json.MarshalIndent(wishes, "", "  ")
+		return File{Name: "wishes.json", ContentType: "application/json", Data: data}, err
+	case "html":
+		data, err := renderHTML(wishes, opts)
+		return File{Name: "wishes.html", ContentType: "text/html; charset=utf-8", Data: data}, err
+	case "md":
+		return File{Name: "wishes.md", ContentType: "text/markdown; charset=utf-8", Data: renderMarkdown(wishes, opts)}, nil
+	}
+	return File{}, ErrUnknownFormat
+}
+
+// renderCSV — таблица для Excel и Google Таблиц: BOM, CRLF, даты в часовом поясе свадьбы
+func renderCSV(wishes []models.Wish, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(utf8BOM)
+	out := csv.NewWriter(&buf)
+	out.UseCRLF = true
+	out.Write([]string{"id", "name", "message", "status", "created_at", "guest_id"})
+	for _, w := range wishes {
+		guestID := ""
+		if w.GuestID != nil {
+			guestID = strconv.Itoa(*w.GuestID)
+		}
+		out.Write([]string{
+			strconv.Itoa(w.ID),
+			csvCell(plain(w.Name)),
+			csvCell(plain(w.Message)),
+			w.Status,
+			w.CreatedAt.In(opts.Location).Format("2006-01-02 15:04"),
+			guestID,
+		})
+	}
+	out.Flush()
+	return buf.Bytes(), out.Error()
+}
+
+// plain — текст гостя без экранирования: в базе он уже экранирован cleanInput
+func plain(s string) string {
+	return html.UnescapeString(s)
+}
+
+// csvCell не даёт Excel принять текст гостя за формулу: «=», «+», «-» и «@» в начале экранируются «'»
+func csvCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// renderMarkdown — книга пожеланий в Markdown, старые первыми
+func renderMarkdown(wishes []models.Wish, opts Options) []byte {
+	var b strings.Builder
+	b.WriteString("# " + opts.Title + "\n\n")
+	for _, w := range chronological(wishes) {
+		b.WriteString(fmt.Sprintf("## %s\n\n", markdownEscaper.Replace(plain(w.Name))))
+		for _, line := range strings.Split(plain(w.Message), "\n") {
+			b.WriteString("> " + markdownEscaper.Replace(line) + "\n")
+		}
+		b.WriteString(fmt.Sprintf("\n*%s*\n\n", FormatDate(w.CreatedAt, opts.Location)))
+	}
+	if len(wishes) == 0 {
+		b.WriteString("Пока нет пожеланий.\n")
+	}
+	return []byte(b.String())
+}
+
+// markdownEscaper экранирует символы разметки в тексте гостей
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "#", `\#`, "[", `\[`, "]", `\]`, "<", "&lt;", ">", "&gt;",
+)
+
+// chronological возвращает копию списка в порядке написания (хранилище отдаёт новые первыми)
+func chronological(wishes []models.Wish) []models.Wish {
+	out := make([]models.Wish, len(wishes))
+	for i, w := range wishes {
+		out[len(wishes)-1-i] = w
+	}
+	return out
+}
+
+var monthsGenitive = [...]string{
+	"января", "февраля", "марта", "апреля", "мая", "июня",
+	"июля", "августа", "сентября", "октября", "ноября", "декабря",
+}
+
+// FormatDate — дата по-русски в часовом поясе свадьбы: «12 июля 2025, 18:30»
+func FormatDate(t time.Time, loc *time.Location) string {
+	t = t.In(loc)
+	return fmt.Sprintf("%d %s %d, %s", t.Day(), monthsGenitive[t.Month()-1], t.Year(), t.Format("15:04"))
+}
+
+// countWishes — «1 пожелание», «3 пожелания», «25 пожеланий»
+func countWishes(n int) string {
+	word := "пожеланий"
+	switch {
+	case n%100 >= 11 && n%100 <= 14:
+	case n%10 == 1:
+		word = "пожелание"
+	case n%10 >= 2 && n%10 <= 4:
+		word = "пожелания"
+	}
+	return fmt.Sprintf("%d %s", n, word)
+}
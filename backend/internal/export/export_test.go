@@ -0,0 +1,89 @@
+// backend/internal/export/export_test.go
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"html"
+	"strings"
+	"testing"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+// stored — пожелание, как его сохраняет AddWish: текст уже экранирован
+func stored(name, message string) models.Wish {
+	return models.Wish{
+		ID:        1,
+		Name:      html.EscapeString(name),
+		Message:   html.EscapeString(message),
+		Status:    models.StatusApproved,
+		CreatedAt: time.Date(2025, 7, 12, 18, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestRenderUnescapesStoredText(t *testing.T) {
+	wishes := []models.Wish{stored("Д'Артаньян & Ко", `Кричим "горько"!`)}
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		// В CSV и Markdown текст как его написал гость
+		{"csv", []string{"Д'Артаньян & Ко", `Кричим ""горько""!`}},
+		{"md", []string{"## Д'Артаньян & Ко", `> Кричим "горько"!`}},
+		// В HTML экранирует только шаблон, один раз
+		{"html", []string{"Д&#39;Артаньян &amp; Ко", "Кричим &#34;горько&#34;!"}},
+	}
+	for _, tt := range tests {
+		file, err := Render(tt.format, wishes, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := string(file.Data)
+		for _, want := range tt.want {
+			if !strings.Contains(out, want) {
+				t.Errorf("%s: нет %q в\n%s", tt.format, want, out)
+			}
+		}
+		if strings.Contains(out, "&amp;#39;") || (tt.format != "html" && strings.Contains(out, "&#39;")) {
+			t.Errorf("%s: текст экранирован лишний раз:\n%s", tt.format, out)
+		}
+	}
+}
+
+func TestRenderJSONKeepsStoredText(t *testing.T) {
+	// JSON — формат восстановления: validateRestored сам раскодирует текст
+	wishes := []models.Wish{stored("Д'Артаньян & Ко", `Кричим "горько"!`)}
+	file, err := Render("json", wishes, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []models.Wish
+	if err := json.Unmarshal(file.Data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Name != wishes[0].Name || got[0].Message != wishes[0].Message {
+		t.Errorf("получили %+v", got)
+	}
+}
+
+func TestRenderCSVFormulas(t *testing.T) {
+	wishes := []models.Wish{stored("=HYPERLINK(\"http://evil\")", "+1 к пожеланиям"), stored("@Аня", "-Горько!")}
+	file, err := Render("csv", wishes, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(file.Data), utf8BOM))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows[1:] {
+		for _, cell := range row[1:3] {
+			if !strings.HasPrefix(cell, "'") {
+				t.Errorf("ячейка %q не защищена от формулы", cell)
+			}
+		}
+	}
+}
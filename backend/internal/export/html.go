@@ -0,0 +1,65 @@
+// backend/internal/export/html.go
+package export
+
+import (
+	"bytes"
+	"html/template"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+// guestbookTemplate — печатная книга пожеланий: по карточке на пожелание,
+// карточки не разрываются между страницами при печати
+var guestbookTemplate = template.Must(template.New("guestbook").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+  @page { size: A4; margin: 18mm 16mm; }
+  body { font-family: Georgia, "Times New Roman", serif; color: #3b3131; max-width: 720px; margin: 0 auto; padding: 24px; background: #fffdf9; }
+  h1 { font-weight: normal; text-align: center; font-size: 34px; letter-spacing: 1px; margin: 8px 0 4px; }
+  .subtitle { text-align: center; color: #9a8478; margin-bottom: 32px; font-style: italic; }
+  .wish { border-top: 1px solid #e8dcd2; padding: 18px 4px; page-break-inside: avoid; break-inside: avoid; }
+  .message { font-size: 17px; line-height: 1.55; white-space: pre-wrap; margin: 0 0 10px; }
+  .author { font-weight: bold; }
+  .date { color: #9a8478; font-size: 13px; margin-left: 8px; }
+  .empty { text-align: center; color: #9a8478; }
+  @media print { body { background: none; padding: 0; } }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="subtitle">{{.Count}} · {{.Generated}}</div>
+{{range .Wishes}}<div class="wish">
+  <p class="message">{{.Message}}</p>
+  <span class="author">{{.Name}}</span><span class="date">{{.Date}}</span>
+</div>
+{{else}}<p class="empty">Пока нет пожеланий.</p>
+{{end}}</body>
+</html>
+`))
+
+type guestbookEntry struct {
+	Name    string
+	Message string
+	Date    string
+}
+
+// renderHTML — книга пожеланий для печати, старые первыми; html/template сам экранирует текст гостей
+func renderHTML(wishes []models.Wish, opts Options) ([]byte, error) {
+	entries := make([]guestbookEntry, 0, len(wishes))
+	for _, w := range chronological(wishes) {
+		entries = append(entries, guestbookEntry{Name: plain(w.Name), Message: plain(w.Message), Date: FormatDate(w.CreatedAt, opts.Location)})
+	}
+
+	var buf bytes.Buffer
+	err := guestbookTemplate.Execute(&buf, struct {
+		Title     string
+		Count     string
+		Generated string
+		Wishes    []guestbookEntry
+	}{opts.Title, countWishes(len(entries)), FormatDate(time.Now(), opts.Location), entries})
+	return buf.Bytes(), err
+}
@@ -0,0 +1,129 @@
+// backend/internal/handlers/admins.go
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+)
+
+// roleOf возвращает роль пользователя или пустую строку, если он не администратор
+func (h *Handler) roleOf(ctx context.Context, userID int64) string {
+	role, err := h.admins.Role(ctx, userID)
+	if err != nil {
+		log.Printf("❌ Ошибка проверки прав: %v", err)
+		return ""
+	}
+	return role
+}
+
+// roleLabel — название роли по-русски
+func roleLabel(role string) string {
+	switch role {
+	case models.RoleOwner:
+		return "👑 владелец"
+	case models.RoleModerator:
+		return "🛡 модератор"
+	}
+	return "👀 наблюдатель"
+}
+
+// adminCommand — /admin, /admin add ID роль [имя], /admin remove ID
+func (h *Handler) adminCommand(ctx context.Context, req commandRequest, args []string) {
+	chatID := req.chatID
+	usage := "Использование:\n/admin — список\n/admin add 123456 moderator Имя\n/admin remove 123456\n\n" +
+		"Роли: viewer — просмотр, moderator — модерация и удаление, owner — всё."
+
+	if len(args) == 0 {
+		list, err := h.admins.List(ctx)
+		if err != nil {
+			log.Printf("❌ Ошибка запроса к БД: %v", err)
+			h.sendTelegramMessage(chatID, "❌ Ошибка базы данных.")
+			return
+		}
+		var b strings.Builder
+		b.WriteString("👥 <b>Администраторы бота</b>:\n\n")
+		for _, a := range list {
+			b.WriteString(fmt.Sprintf("<code>%d</code> %s", a.ChatID, roleLabel(a.Role)))
+			if a.Name != "" {
+				b.WriteString(" — " + html.EscapeString(a.Name))
+			}
+			if h.admins.Static(a.ChatID) {
+				b.WriteString(" (из настроек)")
+			}
+			b.WriteString("\n")
+		}
+		h.sendTelegramMessage(chatID, b.String()+"\n"+usage)
+		return
+	}
+
+	if len(args) < 2 || (args[0] != "add" && args[0] != "remove") {
+		h.sendTelegramMessage(chatID, usage)
+		return
+	}
+	id, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		h.sendTelegramMessage(chatID, "❌ Укажи числовой chat ID пользователя.\n\n"+usage)
+		return
+	}
+	if h.admins.Static(id) {
+		h.sendTelegramMessage(chatID, "❌ Этот администратор задан в настройках сервера (CHAT_ID / ADMINS), его нельзя изменить командой.")
+		return
+	}
+
+	if args[0] == "remove" {
+		before, err := h.store.GetAdmin(ctx, id)
+		if errors.Is(err, store.ErrNotFound) {
+			h.sendTelegramMessage(chatID, "❌ Такого администратора нет.")
+			return
+		}
+		if err != nil {
+			log.Printf("❌ Ошибка запроса к БД: %v", err)
+			h.sendTelegramMessage(chatID, "❌ Ошибка базы данных.")
+			return
+		}
+		deleted, err := h.store.DeleteAdmin(ctx, id)
+		if err != nil {
+			log.Printf("❌ Ошибка удаления администратора: %v", err)
+			h.sendTelegramMessage(chatID, "❌ Ошибка базы данных.")
+			return
+		}
+		if !deleted {
+			h.sendTelegramMessage(chatID, "❌ Такого администратора нет.")
+			return
+		}
+		h.audit(ctx, req.userID, models.AuditAdminRemove, nil, before, nil)
+		h.sendTelegramMessage(chatID, fmt.Sprintf("✅ Администратор <code>%d</code> удалён.", id))
+		return
+	}
+
+	if len(args) < 3 || !models.ValidRole(args[2]) {
+		h.sendTelegramMessage(chatID, "❌ Укажи роль: viewer, moderator или owner.\n\n"+usage)
+		return
+	}
+	// Имя хранится как есть и экранируется при выводе
+	admin := models.Admin{ChatID: id, Role: args[2], Name: strings.TrimSpace(strings.Join(args[3:], " "))}
+	if utf8.RuneCountInString(admin.Name) > 100 {
+		h.sendTelegramMessage(chatID, "❌ Имя должно быть не длиннее 100 символов.")
+		return
+	}
+	var before any
+	if old, err := h.store.GetAdmin(ctx, id); err == nil {
+		before = old
+	}
+	if err := h.store.SaveAdmin(ctx, &admin); err != nil {
+		log.Printf("❌ Ошибка сохранения администратора: %v", err)
+		h.sendTelegramMessage(chatID, "❌ Ошибка базы данных.")
+		return
+	}
+	h.audit(ctx, req.userID, models.AuditAdminAdd, nil, before, admin)
+	h.sendTelegramMessage(chatID, fmt.Sprintf("✅ <code>%d</code> теперь %s. Пусть напишет боту /start.", id, roleLabel(admin.Role)))
+}
@@ -0,0 +1,179 @@
+// backend/internal/handlers/audit.go
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"wedding-backend/internal/export"
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+)
+
+const (
+	// auditShown — сколько последних событий показывает /audit
+	auditShown = 20
+	// auditShownIDs — сколько ID пожеланий перечислять в строке события
+	auditShownIDs = 5
+)
+
+// auditLabels — действия журнала по-русски
+var auditLabels = map[string]string{
+	models.AuditApprove:     "✅ одобрил",
+	models.AuditReject:      "🚫 отклонил",
+	models.AuditPending:     "⏳ вернул на модерацию",
+	models.AuditDelete:      "🗑 удалил",
+	models.AuditDeleteAll:   "🗑 удалил все",
+	models.AuditUndelete:    "↩️ вернул из корзины",
+	models.AuditUndeleteAll: "↩️ отменил удаление всех",
+	models.AuditRestore:     "♻️ восстановил из бэкапа",
+	models.AuditPurge:       "🧹 очистил корзину",
+	models.AuditAdminAdd:    "👤 назначил администратора",
+	models.AuditAdminRemove: "👤 удалил администратора",
+	models.AuditGuests:      "📋 загрузил гостей",
+}
+
+// audit записывает действие в журнал; before и after — снимки до и после (nil — без снимка).
+// Ошибка журнала только логируется: само действие уже выполнено.
+func (h *Handler) audit(ctx context.Context, actorID int64, action string, wishIDs []int, before, after any) {
+	event := models.AuditEvent{ActorID: actorID, Action: action, WishIDs: wishIDs}
+	var err error
+	if event.Before, err = auditSnapshot(before); err == nil {
+		event.After, err = auditSnapshot(after)
+	}
+	if err == nil {
+		err = h.store.AddAudit(ctx, &event)
+	}
+	if err != nil {
+		log.Printf("❌ Ошибка записи в журнал аудита (%s от %d): %v", action, actorID, err)
+	}
+}
+
+func auditSnapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// wishIDs — ID пожеланий для записи журнала
+func wishIDs(wishes []models.Wish) []int {
+	ids := make([]int, len(wishes))
+	for i, w := range wishes {
+		ids[i] = w.ID
+	}
+	return ids
+}
+
+// auditArg — /audit, /audit ID или /audit csv|json
+func auditArg(args []string, req *commandRequest) bool {
+	if len(args) == 0 {
+		return true
+	}
+	if len(args) != 1 {
+		return false
+	}
+	if format := strings.ToLower(args[0]); slices.Contains(export.AuditFormats, format) {
+		req.text = format
+		return true
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id <= 0 {
+		return false
+	}
+	req.id = id
+	return true
+}
+
+// cmdAudit — /audit: последние действия; /audit ID — история пожелания; /audit csv|json — весь журнал файлом
+func (h *Handler) cmdAudit(ctx context.Context, req commandRequest) {
+	if req.text != "" {
+		events, err := h.store.ListAudit(ctx, store.AuditFilter{})
+		if err != nil {
+			log.Printf("❌ Ошибка запроса к БД: %v", err)
+			h.sendTelegramMessage(req.chatID, "❌ Ошибка базы данных.")
+			return
+		}
+		file, err := export.RenderAudit(req.text, events, export.Options{Location: h.cfg.Location})
+		if err != nil {
+			log.Printf("❌ Ошибка выгрузки журнала: %v", err)
+			h.sendTelegramMessage(req.chatID, "❌ Не удалось собрать файл.")
+			return
+		}
+		h.sendTelegramFile(req.chatID, file.Name, file.Data)
+		return
+	}
+
+	events, err := h.store.ListAudit(ctx, store.AuditFilter{WishID: req.id, Limit: auditShown})
+	if err != nil {
+		log.Printf("❌ Ошибка запроса к БД: %v", err)
+		h.sendTelegramMessage(req.chatID, "❌ Ошибка базы данных.")
+		return
+	}
+
+	title := "📜 <b>Журнал действий</b>"
+	if req.id > 0 {
+		title = fmt.Sprintf("📜 <b>История пожелания №%d</b>", req.id)
+	}
+	if len(events) == 0 {
+		h.sendTelegramMessage(req.chatID, title+"\n\nЗаписей нет.")
+		return
+	}
+
+	names := h.adminNames(ctx)
+	var sb strings.Builder
+	sb.WriteString(title + "\n\n")
+	for _, e := range events {
+		sb.WriteString(h.auditLine(e, names) + "\n")
+	}
+	sb.WriteString("\nВесь журнал файлом: /audit csv или /audit json")
+	h.sendTelegramMessage(req.chatID, sb.String())
+}
+
+// auditLine — «12 июля 2025, 18:30 · Аня ✅ одобрил №5»
+func (h *Handler) auditLine(e models.AuditEvent, names map[int64]string) string {
+	actor := "сервер"
+	if e.ActorID != 0 {
+		actor = fmt.Sprintf("<code>%d</code>", e.ActorID)
+		if name := names[e.ActorID]; name != "" {
+			actor = html.EscapeString(name)
+		}
+	}
+	label := auditLabels[e.Action]
+	if label == "" {
+		label = html.EscapeString(e.Action)
+	}
+
+	line := fmt.Sprintf("%s · %s %s", export.FormatDate(e.CreatedAt, h.cfg.Location), actor, label)
+	if len(e.WishIDs) > 0 {
+		ids := make([]string, 0, auditShownIDs)
+		for _, id := range e.WishIDs[:min(len(e.WishIDs), auditShownIDs)] {
+			ids = append(ids, "№"+strconv.Itoa(id))
+		}
+		line += " " + strings.Join(ids, ", ")
+		if len(e.WishIDs) > auditShownIDs {
+			line += fmt.Sprintf(" и ещё %d", len(e.WishIDs)-auditShownIDs)
+		}
+	}
+	return line
+}
+
+// adminNames — имена администраторов для журнала
+func (h *Handler) adminNames(ctx context.Context) map[int64]string {
+	names := make(map[int64]string)
+	list, err := h.admins.List(ctx)
+	if err != nil {
+		log.Printf("❌ Ошибка запроса к БД: %v", err)
+		return names
+	}
+	for _, a := range list {
+		names[a.ChatID] = a.Name
+	}
+	return names
+}
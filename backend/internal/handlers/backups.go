@@ -0,0 +1,159 @@
+// backend/internal/handlers/backups.go
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+
+	"wedding-backend/internal/backup"
+	"wedding-backend/internal/export"
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/telegram"
+)
+
+// maxBackupButtons — сколько последних архивов предлагать для восстановления
+const maxBackupButtons = 10
+
+// backupsArg — /backups или /backups now
+func backupsArg(args []string, req *commandRequest) bool {
+	if len(args) == 0 {
+		return true
+	}
+	if len(args) == 1 && strings.EqualFold(args[0], "now") {
+		req.text = "now"
+		return true
+	}
+	return false
+}
+
+// cmdBackups — /backups: архивы в каталоге с кнопками восстановления; /backups now — снять копию сейчас
+func (h *Handler) cmdBackups(ctx context.Context, req commandRequest) {
+	if req.text == "now" {
+		b, err := h.backups.Create(ctx)
+		if err != nil {
+			log.Printf("❌ Ошибка резервного копирования: %v", err)
+			h.sendTelegramMessage(req.chatID, "❌ Не удалось снять резервную копию.")
+			return
+		}
+		h.sendBackup(ctx, req.chatID, b)
+		return
+	}
+
+	if h.backups.Dir == "" {
+		h.sendTelegramMessage(req.chatID, "📭 Каталог резервных копий не настроен (BACKUP_DIR). "+
+			"Чтобы восстановить данные, пришлите архив <code>backup-….tar.gz</code> из этого чата.")
+		return
+	}
+	list, err := h.backups.List()
+	if err != nil {
+		log.Printf("❌ Ошибка чтения каталога резервных копий: %v", err)
+		h.sendTelegramMessage(req.chatID, "❌ Не удалось прочитать каталог резервных копий.")
+		return
+	}
+	if len(list) == 0 {
+		h.sendTelegramMessage(req.chatID, "📭 Резервных копий пока нет. /backups now — снять сейчас.")
+		return
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "💾 <b>Резервные копии</b> (хранится последних: %d)\n\n", h.backups.Keep)
+	var buttons [][]telegram.InlineButton
+	for i, info := range list {
+		date := export.FormatDate(info.CreatedAt, h.cfg.Location)
+		fmt.Fprintf(&sb, "• %s — %d КБ\n", date, (info.Size+1023)/1024)
+		if i < maxBackupButtons {
+			buttons = append(buttons, []telegram.InlineButton{{Text: "♻️ " + date, Data: "backup:" + info.Name}})
+		}
+	}
+	sb.WriteString("\nВыберите копию, чтобы сравнить её с базой перед восстановлением.")
+	if _, err := h.sendTelegramButtons(req.chatID, sb.String(), buttons); err != nil {
+		log.Printf("❌ Ошибка отправки сообщения: %v", err)
+	}
+}
+
+// onBackup — кнопка восстановления из каталога: backup:<имя архива>
+func (h *Handler) onBackup(ctx context.Context, cq *telegram.CallbackQuery, args []string) {
+	if len(args) != 1 {
+		h.answerCallbackQuery(cq.ID, "Неизвестная кнопка")
+		return
+	}
+	archive, err := h.backups.Load(args[0])
+	if errors.Is(err, backup.ErrNotFound) {
+		h.answerCallbackQuery(cq.ID, "Архив уже удалён")
+		return
+	}
+	h.answerCallbackQuery(cq.ID, "")
+
+	req := commandRequest{chatID: cq.Message.Chat.ID, userID: cq.From.ID}
+	if err != nil {
+		h.reportBrokenArchive(req.chatID, err)
+		return
+	}
+	h.restoreFromArchive(ctx, req, archive)
+}
+
+// restoreFromBackupFile — загруженный в чат архив backup-….tar.gz
+func (h *Handler) restoreFromBackupFile(ctx context.Context, req commandRequest, data []byte) {
+	archive, err := backup.Open(data)
+	if err != nil {
+		h.reportBrokenArchive(req.chatID, err)
+		return
+	}
+	h.restoreFromArchive(ctx, req, archive)
+}
+
+// restoreFromArchive восстанавливает пожелания из проверенного архива через предпросмотр restoreFromJSON.
+// Гости, ответы, администраторы и журнал лежат в архиве для ручного восстановления.
+func (h *Handler) restoreFromArchive(ctx context.Context, req commandRequest, archive backup.Archive) {
+	wishes, ok := archive.Files[backup.WishesFile]
+	if !ok {
+		h.sendTelegramMessage(req.chatID, "❌ В архиве нет пожеланий.")
+		return
+	}
+	h.sendTelegramMessage(req.chatID, fmt.Sprintf("✅ Архив от %s проверен: контрольные суммы совпадают.",
+		export.FormatDate(archive.Manifest.CreatedAt, h.cfg.Location)))
+	h.restoreFromJSON(ctx, req, wishes)
+}
+
+func (h *Handler) reportBrokenArchive(chatID int64, err error) {
+	log.Printf("❌ Ошибка чтения архива: %v", err)
+	if errors.Is(err, backup.ErrCorrupt) {
+		h.sendTelegramMessage(chatID, "❌ "+html.EscapeString(err.Error())+".")
+		return
+	}
+	h.sendTelegramMessage(chatID, "❌ Не удалось прочитать архив.")
+}
+
+// SendBackup отправляет резервную копию всем владельцам бота; вызывается по расписанию
+func (h *Handler) SendBackup(ctx context.Context, b backup.Backup) {
+	if !h.bot.Configured() {
+		return
+	}
+	list, err := h.admins.List(ctx)
+	if err != nil {
+		log.Printf("❌ Ошибка запроса к БД: %v", err)
+		return
+	}
+	for _, a := range list {
+		if a.Role == models.RoleOwner {
+			h.sendBackup(ctx, a.ChatID, b)
+		}
+	}
+}
+
+func (h *Handler) sendBackup(ctx context.Context, chatID int64, b backup.Backup) {
+	counts := b.Manifest.Counts
+	caption := fmt.Sprintf("💾 Резервная копия от %s\nПожеланий: %d, гостей: %d, ответов: %d\nSHA-256: <code>%s</code>",
+		export.FormatDate(b.Manifest.CreatedAt, h.cfg.Location),
+		counts[backup.WishesFile], counts[backup.GuestsFile], counts[backup.RSVPsFile], b.Checksum)
+
+	ctx, cancel := context.WithTimeout(ctx, botTimeout)
+	defer cancel()
+	if _, err := h.bot.SendDocument(ctx, chatID, b.Name, b.Data, caption); err != nil {
+		log.Printf("❌ Ошибка отправки резервной копии: %v", err)
+	}
+}
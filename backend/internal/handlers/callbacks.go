@@ -0,0 +1,243 @@
+// backend/internal/handlers/callbacks.go
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"wedding-backend/internal/events"
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+	"wedding-backend/internal/telegram"
+)
+
+// confirmTTL — сколько живёт кнопка подтверждения опасной команды
+const confirmTTL = 10 * time.Minute
+
+// callbackHandler обрабатывает нажатие кнопки; args — части callback_data после префикса
+type callbackHandler func(ctx context.Context, cq *telegram.CallbackQuery, args []string)
+
+// callbackRoute — обработчик кнопки и минимальная роль для нажатия
+type callbackRoute struct {
+	handle callbackHandler
+	role   string
+}
+
+// callbackRoutes — префикс callback_data → обработчик.
+// Подтверждение доступно любому: нажать его может только тот, кто вызвал команду.
+func (h *Handler) callbackRoutes() map[string]callbackRoute {
+	return map[string]callbackRoute{
+		"mod":     {h.onModerate, models.RoleModerator},
+		"confirm": {h.onConfirm, models.RoleViewer},
+		"page":    {h.onPage, models.RoleViewer},
+		"backup":  {h.onBackup, models.RoleOwner},
+		"undo":    {h.onUndo, models.RoleModerator},
+	}
+}
+
+// handleCallback разбирает callback_data вида prefix:arg1:arg2 и вызывает обработчик
+func (h *Handler) handleCallback(ctx context.Context, cq *telegram.CallbackQuery, role string) {
+	parts := strings.Split(cq.Data, ":")
+	route, ok := h.callbackRoutes()[parts[0]]
+	if !ok {
+		log.Printf("⚠️ Неизвестный callback: %q", cq.Data)
+		h.answerCallbackQuery(cq.ID, "Неизвестная кнопка")
+		return
+	}
+	if !models.RoleAllows(role, route.role) {
+		h.answerCallbackQuery(cq.ID, "⛔️ Недостаточно прав")
+		return
+	}
+	route.handle(ctx, cq, parts[1:])
+}
+
+// onModerate — кнопки «Одобрить / Отклонить»: mod:<status>:<id>
+func (h *Handler) onModerate(ctx context.Context, cq *telegram.CallbackQuery, args []string) {
+	if len(args) != 2 || !models.ValidStatus(args[0]) {
+		h.answerCallbackQuery(cq.ID, "Неизвестная кнопка")
+		return
+	}
+	status := args[0]
+	id, err := strconv.Atoi(args[1])
+	if err != nil {
+		h.answerCallbackQuery(cq.ID, "Неизвестная кнопка")
+		return
+	}
+
+	wish, err := h.store.Get(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		h.answerCallbackQuery(cq.ID, "Пожелание уже удалено")
+		h.editTelegramMessage(cq.Message.Chat.ID, cq.Message.MessageID, fmt.Sprintf("🗑 Пожелание №%d удалено.", id))
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Ошибка запроса к БД: %v", err)
+		h.answerCallbackQuery(cq.ID, "❌ Ошибка базы данных")
+		return
+	}
+
+	if err := h.store.SetStatus(ctx, id, status); err != nil {
+		log.Printf("❌ Ошибка смены статуса: %v", err)
+		h.answerCallbackQuery(cq.ID, "❌ Ошибка базы данных")
+		return
+	}
+
+	// Публичная лента меняется, только если пожелание появилось или пропало из неё
+	before := wish
+	previous := wish.Status
+	wish.Status = status
+	h.audit(ctx, cq.From.ID, moderationAction(status), []int{id}, before, wish)
+	if status == models.StatusApproved && previous != models.StatusApproved {
+		h.feed.Publish(events.WishCreated, publicWish(wish))
+	} else if status != models.StatusApproved && previous == models.StatusApproved {
+		h.feed.Publish(events.WishDeleted, map[string]int{"id": id})
+	}
+
+	verdict := "✅ Одобрено"
+	if status == models.StatusRejected {
+		verdict = "🚫 Отклонено"
+	}
+	h.editTelegramMessage(cq.Message.Chat.ID, cq.Message.MessageID, wishNotice(models.Wish{
+		ID: wish.ID, Name: wish.Name, Message: wish.Message,
+	})+"\n\n"+verdict)
+	h.answerCallbackQuery(cq.ID, verdict)
+}
+
+// confirmAction — отложенная опасная операция; возвращает текст результата
+// и кнопки под ним (например, «Отменить удаление»), nil — без кнопок
+type confirmAction func(ctx context.Context) (string, [][]telegram.InlineButton)
+
+// confirmKey — сообщение с кнопками, к которому привязано подтверждение
+type confirmKey struct {
+	chatID    int64
+	messageID int
+}
+
+// confirmChoice — кнопка подтверждения: key уходит в callback_data как confirm:<key>
+type confirmChoice struct {
+	key  string
+	text string
+	run  confirmAction
+}
+
+type pendingConfirm struct {
+	choices []confirmChoice
+	userID  int64
+	expires time.Time
+}
+
+// confirmations хранит ожидающие подтверждения операции в памяти процесса.
+// Подтверждение действует только для того сообщения, под которым нажата кнопка.
+type confirmations struct {
+	mu      sync.Mutex
+	pending map[confirmKey]pendingConfirm
+}
+
+func newConfirmations() *confirmations {
+	return &confirmations{pending: make(map[confirmKey]pendingConfirm)}
+}
+
+func (c *confirmations) add(key confirmKey, userID int64, choices []confirmChoice) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, p := range c.pending {
+		if now.After(p.expires) {
+			delete(c.pending, k)
+		}
+	}
+	c.pending[key] = pendingConfirm{choices: choices, userID: userID, expires: now.Add(confirmTTL)}
+}
+
+// take забирает варианты операции; повторное нажатие или устаревшая кнопка вернут nil.
+// Нажатие другого пользователя (в общем чате) операцию не трогает: foreign == true.
+func (c *confirmations) take(key confirmKey, userID int64) (choices []confirmChoice, foreign bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, ok := c.pending[key]
+	if ok && p.userID != userID && time.Now().Before(p.expires) {
+		return nil, true
+	}
+	delete(c.pending, key)
+	if !ok || time.Now().After(p.expires) {
+		return nil, false
+	}
+	return p.choices, false
+}
+
+// askConfirmation отправляет вопрос с кнопками «Подтвердить / Отмена»
+// и запоминает операцию за этим сообщением; подтвердить может только userID
+func (h *Handler) askConfirmation(chatID, userID int64, question, confirmText string, run confirmAction) {
+	h.askChoice(chatID, userID, question, []confirmChoice{{key: "yes", text: confirmText, run: run}})
+}
+
+// askChoice — подтверждение с несколькими вариантами операции и кнопкой «Отмена»
+func (h *Handler) askChoice(chatID, userID int64, question string, choices []confirmChoice) {
+	row := make([]telegram.InlineButton, 0, len(choices)+1)
+	for _, c := range choices {
+		row = append(row, telegram.InlineButton{Text: c.text, Data: "confirm:" + c.key})
+	}
+	row = append(row, telegram.InlineButton{Text: "✖️ Отмена", Data: "confirm:no"})
+
+	messageID, err := h.sendTelegramButtons(chatID, question, [][]telegram.InlineButton{row})
+	if err != nil {
+		log.Printf("❌ Ошибка отправки подтверждения: %v", err)
+		h.sendTelegramMessage(chatID, "❌ Не удалось отправить подтверждение.")
+		return
+	}
+	h.confirms.add(confirmKey{chatID: chatID, messageID: messageID}, userID, choices)
+}
+
+// onConfirm — кнопки подтверждения: confirm:<вариант> / confirm:no
+func (h *Handler) onConfirm(ctx context.Context, cq *telegram.CallbackQuery, args []string) {
+	chatID, messageID := cq.Message.Chat.ID, cq.Message.MessageID
+	choices, foreign := h.confirms.take(confirmKey{chatID: chatID, messageID: messageID}, cq.From.ID)
+	if foreign {
+		h.answerCallbackQuery(cq.ID, "Подтвердить может только тот, кто вызвал команду")
+		return
+	}
+	if choices == nil {
+		h.answerCallbackQuery(cq.ID, "Подтверждение устарело")
+		h.editTelegramMessage(chatID, messageID, "⌛ Подтверждение устарело, повторите команду.")
+		return
+	}
+
+	var run confirmAction
+	for _, c := range choices {
+		if len(args) == 1 && args[0] == c.key {
+			run = c.run
+		}
+	}
+	if run == nil {
+		h.answerCallbackQuery(cq.ID, "Отменено")
+		h.editTelegramMessage(chatID, messageID, "✅ Операция отменена.")
+		return
+	}
+
+	result, buttons := run(ctx)
+	h.answerCallbackQuery(cq.ID, "Готово")
+	if buttons != nil {
+		h.editTelegramButtons(chatID, messageID, result, buttons)
+		return
+	}
+	h.editTelegramMessage(chatID, messageID, result)
+}
+
+// moderationAction — действие журнала для нового статуса
+func moderationAction(status string) string {
+	switch status {
+	case models.StatusApproved:
+		return models.AuditApprove
+	case models.StatusRejected:
+		return models.AuditReject
+	}
+	return models.AuditPending
+}
@@ -0,0 +1,70 @@
+// backend/internal/handlers/challenge.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"wedding-backend/internal/challenge"
+)
+
+// GET /api/challenge — подписанный токен для формы пожеланий и, если включена, задача на вычисление
+func (h *Handler) Challenge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		errorResponse(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.cfg.Challenge == nil {
+		errorResponse(w, "Проверка формы отключена", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(h.cfg.Challenge.Issue(time.Now()))
+}
+
+// challengeOK проверяет токен формы из запроса, не тратя его, и при ошибке отвечает сам
+func (h *Handler) challengeOK(w http.ResponseWriter, req wishRequest) bool {
+	if h.cfg.Challenge == nil {
+		return true
+	}
+	return challengeResult(w, req, h.cfg.Challenge.Check(req.Challenge, req.Solution, time.Now()))
+}
+
+// consumeChallenge тратит токен принятого пожелания; повторная отправка с ним не пройдёт
+func (h *Handler) consumeChallenge(w http.ResponseWriter, req wishRequest) bool {
+	if h.cfg.Challenge == nil {
+		return true
+	}
+	return challengeResult(w, req, h.cfg.Challenge.Consume(req.Challenge, time.Now()))
+}
+
+// releaseChallenge возвращает токен, если принятое пожелание не удалось сохранить
+func (h *Handler) releaseChallenge(req wishRequest) {
+	if h.cfg.Challenge != nil {
+		h.cfg.Challenge.Release(req.Challenge)
+	}
+}
+
+// challengeResult отвечает гостю на ошибку токена. Устаревший или негодный токен — 403:
+// форма берёт новый; со «слишком быстро» токен ещё годен и остаётся у формы.
+func challengeResult(w http.ResponseWriter, req wishRequest, err error) bool {
+	if err == nil {
+		return true
+	}
+	log.Printf("⚠️ Пожелание от %q не прошло проверку формы: %v", req.Name, err)
+
+	switch {
+	case errors.Is(err, challenge.ErrTooFast):
+		errorResponse(w, "Слишком быстро! Перечитайте пожелание и отправьте ещё раз", http.StatusBadRequest)
+	case errors.Is(err, challenge.ErrExpired):
+		errorResponse(w, "Форма устарела, отправьте пожелание ещё раз", http.StatusForbidden)
+	default:
+		errorResponse(w, "Не удалось проверить форму, обновите страницу", http.StatusForbidden)
+	}
+	return false
+}
@@ -0,0 +1,303 @@
+// backend/internal/handlers/commands.go
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+
+	"wedding-backend/internal/events"
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+	"wedding-backend/internal/telegram"
+)
+
+// commandRequest — вызов команды: кто, откуда и с какими разобранными аргументами
+type commandRequest struct {
+	chatID int64
+	userID int64
+	role   string
+
+	id   int      // idArg, optionalIDArg
+	text string   // textArg
+	args []string // anyArgs
+}
+
+// argParser разбирает аргументы команды в req; false — показать подсказку
+type argParser func(args []string, req *commandRequest) bool
+
+// command — команда бота
+type command struct {
+	name        string
+	args        string // подсказка аргументов для справки, например «ID»
+	description string
+	role        string
+	parse       argParser
+	run         func(ctx context.Context, req commandRequest)
+}
+
+// noArgs — команда без аргументов
+func noArgs(args []string, req *commandRequest) bool {
+	return len(args) == 0
+}
+
+// idArg — один положительный числовой ID
+func idArg(args []string, req *commandRequest) bool {
+	if len(args) != 1 {
+		return false
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id <= 0 {
+		return false
+	}
+	req.id = id
+	return true
+}
+
+// optionalIDArg — необязательный положительный номер (0, если не указан)
+func optionalIDArg(args []string, req *commandRequest) bool {
+	return len(args) == 0 || idArg(args, req)
+}
+
+// textArg — непустой текст до конца строки
+func textArg(args []string, req *commandRequest) bool {
+	req.text = strings.Join(args, " ")
+	return req.text != ""
+}
+
+// anyArgs — аргументы разбирает сама команда
+func anyArgs(args []string, req *commandRequest) bool {
+	req.args = args
+	return true
+}
+
+// commands — все команды бота в порядке показа в справке и меню Telegram
+func (h *Handler) commands() []command {
+	return []command{
+		{"start", "", "справка по командам", models.RoleViewer, noArgs, h.cmdStart},
+		{"list", "[страница]", "все пожелания по страницам + JSON-бэкап", models.RoleViewer, optionalIDArg, h.cmdList},
+		{"last", "[N]", "последние N пожеланий (по умолчанию 10)", models.RoleViewer, optionalIDArg, h.cmdLast},
+		{"search", "текст", "поиск по имени и тексту пожелания", models.RoleViewer, textArg, h.cmdSearch},
+		{"pending", "", "пожелания, ждущие модерации", models.RoleViewer, noArgs, h.cmdPending},
+		{"export", "csv|json|html|md", "выгрузка пожеланий (HTML — для печати)", models.RoleViewer, formatArg, h.cmdExport},
+		{"rsvp", "", "сводка ответов гостей", models.RoleViewer, noArgs, h.cmdRSVP},
+		{"guests", "", "CSV со ссылками-приглашениями (загрузите guests.csv, чтобы обновить список)", models.RoleViewer, noArgs, h.cmdGuests},
+		{"failed", "", "недоставленные уведомления", models.RoleViewer, noArgs, h.cmdFailed},
+		{"retry", "ID", "повторить доставку уведомления", models.RoleModerator, idArg, h.cmdRetry},
+		{"delete", "ID", "удалить пожелание в корзину (с подтверждением)", models.RoleModerator, idArg, h.cmdDelete},
+		{"delete_all", "", "удалить всё в корзину (с подтверждением)", models.RoleOwner, noArgs, h.cmdDeleteAll},
+		{"trash", "", "корзина удалённых пожеланий", models.RoleViewer, noArgs, h.cmdTrash},
+		{"undelete", "ID", "вернуть пожелание из корзины", models.RoleModerator, idArg, h.cmdUndelete},
+		{"restore", "", "восстановить из файла wishes.json", models.RoleOwner, noArgs, h.cmdRestore},
+		{"backups", "[now]", "резервные копии: список и восстановление", models.RoleOwner, backupsArg, h.cmdBackups},
+		{"admin", "[add ID роль | remove ID]", "администраторы бота", models.RoleOwner, anyArgs, h.cmdAdmin},
+		{"audit", "[ID | csv | json]", "журнал действий администраторов", models.RoleOwner, auditArg, h.cmdAudit},
+	}
+}
+
+// parseCommand разбирает «/cmd@bot  арг1   арг2». Команда, адресованная другому боту
+// в общем чате, не наша: ok == false.
+func parseCommand(text, botUsername string) (name string, args []string, ok bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "/") {
+		return "", nil, false
+	}
+	name, mention, _ := strings.Cut(fields[0][1:], "@")
+	if mention != "" && botUsername != "" && !strings.EqualFold(mention, botUsername) {
+		return "", nil, false
+	}
+	return strings.ToLower(name), fields[1:], name != ""
+}
+
+// usage — строка справки для команды
+func (c command) usage() string {
+	line := "/" + c.name
+	if c.args != "" {
+		line += " " + html.EscapeString(c.args)
+	}
+	return line + " — " + c.description
+}
+
+// runCommand проверяет права и аргументы и выполняет команду
+func (h *Handler) runCommand(ctx context.Context, req commandRequest, name string, args []string) {
+	for _, c := range h.commands() {
+		if c.name != name {
+			continue
+		}
+		if !models.RoleAllows(req.role, c.role) {
+			h.sendTelegramMessage(req.chatID, fmt.Sprintf("⛔️ Недостаточно прав: нужна роль %s.", roleLabel(c.role)))
+			return
+		}
+		if !c.parse(args, &req) {
+			h.sendTelegramMessage(req.chatID, "❌ Использование: "+c.usage())
+			return
+		}
+		c.run(ctx, req)
+		return
+	}
+	h.sendTelegramMessage(req.chatID, "Неизвестная команда. Используй: /start")
+}
+
+// RegisterCommands узнаёт имя бота и публикует меню команд через setMyCommands.
+// Вызывается при старте, до приёма обновлений.
+func (h *Handler) RegisterCommands(ctx context.Context) error {
+	me, err := h.bot.GetMe(ctx)
+	if err != nil {
+		return err
+	}
+	h.botUsername = me.Username
+
+	var menu []telegram.BotCommand
+	for _, c := range h.commands() {
+		menu = append(menu, telegram.BotCommand{Command: c.name, Description: c.description})
+	}
+	return h.bot.SetMyCommands(ctx, menu)
+}
+
+// === КОМАНДЫ ===
+
+func (h *Handler) cmdStart(ctx context.Context, req commandRequest) {
+	var b strings.Builder
+	b.WriteString("Привет! 🌸\n\nДоступные команды:\n\n")
+	for _, c := range h.commands() {
+		if c.name != "start" && models.RoleAllows(req.role, c.role) {
+			b.WriteString(c.usage() + "\n")
+		}
+	}
+	b.WriteString("\nВаша роль: " + roleLabel(req.role))
+	h.sendTelegramMessage(req.chatID, b.String())
+}
+
+func (h *Handler) cmdPending(ctx context.Context, req commandRequest) {
+	pending, err := h.store.List(ctx, store.ListFilter{Status: models.StatusPending, Limit: 20})
+	if err != nil {
+		log.Printf("❌ Ошибка запроса к БД: %v", err)
+		h.sendTelegramMessage(req.chatID, "❌ Ошибка базы данных.")
+		return
+	}
+	if len(pending) == 0 {
+		h.sendTelegramMessage(req.chatID, "✅ Очередь модерации пуста.")
+		return
+	}
+	// Каждое пожелание отдельным сообщением, чтобы у него были свои кнопки
+	for _, wish := range pending {
+		if _, err := h.sendTelegramButtons(req.chatID, wishNotice(wish), moderationButtons(wish.ID)); err != nil {
+			log.Printf("❌ Ошибка отправки сообщения: %v", err)
+		}
+	}
+}
+
+func (h *Handler) cmdRSVP(ctx context.Context, req commandRequest) {
+	rsvps, err := h.store.ListRSVPs(ctx)
+	if err != nil {
+		log.Printf("❌ Ошибка запроса к БД: %v", err)
+		h.sendTelegramMessage(req.chatID, "❌ Ошибка базы данных.")
+		return
+	}
+	h.sendTelegramMessage(req.chatID, rsvpSummary(rsvps))
+}
+
+func (h *Handler) cmdGuests(ctx context.Context, req commandRequest) {
+	data, count, err := h.guestsCSV(ctx)
+	if err != nil {
+		log.Printf("❌ Ошибка выгрузки гостей: %v", err)
+		h.sendTelegramMessage(req.chatID, "❌ Ошибка базы данных.")
+		return
+	}
+	if count == 0 {
+		h.sendTelegramMessage(req.chatID, "👥 Список гостей пуст. Пришлите файл <code>guests.csv</code> с колонками name, party_size, events.")
+		return
+	}
+	h.sendTelegramMessage(req.chatID, fmt.Sprintf("👥 Гостей в списке: %d", count))
+	h.sendTelegramFile(req.chatID, "guests.csv", data)
+}
+
+func (h *Handler) cmdFailed(ctx context.Context, req commandRequest) {
+	failed, err := h.store.ListOutbox(ctx, models.OutboxDead, 20)
+	if err != nil {
+		log.Printf("❌ Ошибка запроса к БД: %v", err)
+		h.sendTelegramMessage(req.chatID, "❌ Ошибка базы данных.")
+		return
+	}
+	h.sendTelegramMessage(req.chatID, failedSummary(failed))
+}
+
+func (h *Handler) cmdRetry(ctx context.Context, req commandRequest) {
+	err := h.store.RetryOutbox(ctx, req.id)
+	if errors.Is(err, store.ErrNotFound) {
+		h.sendTelegramMessage(req.chatID, "❌ Среди недоставленных нет уведомления с таким ID.")
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Ошибка запроса к БД: %v", err)
+		h.sendTelegramMessage(req.chatID, "❌ Ошибка базы данных.")
+		return
+	}
+	h.outbox.Kick()
+	h.sendTelegramMessage(req.chatID, fmt.Sprintf("🔁 Уведомление #%d снова в очереди.", req.id))
+}
+
+func (h *Handler) cmdDelete(ctx context.Context, req commandRequest) {
+	id := req.id
+	wish, err := h.store.Get(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		h.sendTelegramMessage(req.chatID, "❌ Пожелание с таким ID не найдено.")
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Ошибка запроса к БД: %v", err)
+		h.sendTelegramMessage(req.chatID, "❌ Ошибка базы данных.")
+		return
+	}
+
+	question := fmt.Sprintf("⚠️ Удалить пожелание <b>№%d</b>?\n\n%s: %s",
+		wish.ID, wishHTML(wish.Name), wishHTML(wish.Message))
+	h.askConfirmation(req.chatID, req.userID, question, "🗑 Удалить", func(ctx context.Context) (string, [][]telegram.InlineButton) {
+		deleted, err := h.store.Delete(ctx, id)
+		if err != nil {
+			log.Printf("❌ Ошибка при удалении: %v", err)
+			return "❌ Ошибка базы данных.", nil
+		}
+		if !deleted {
+			return "❌ Пожелание с таким ID не найдено.", nil
+		}
+
+		h.audit(ctx, req.userID, models.AuditDelete, []int{id}, wish, nil)
+		h.feed.Publish(events.WishDeleted, map[string]int{"id": id})
+		return fmt.Sprintf("🗑 Пожелание №%d перенесено в корзину.", id), undoButtons(strconv.Itoa(id))
+	})
+}
+
+func (h *Handler) cmdDeleteAll(ctx context.Context, req commandRequest) {
+	h.askConfirmation(req.chatID, req.userID, "⚠️ Удалить <b>все</b> пожелания? Они попадут в корзину (/trash).", "🗑 Удалить всё",
+		func(ctx context.Context) (string, [][]telegram.InlineButton) {
+			// Снимок для журнала: что именно ушло в корзину
+			wishes, err := h.store.List(ctx, store.ListFilter{})
+			if err != nil {
+				log.Printf("❌ Ошибка запроса к БД: %v", err)
+				return "❌ Ошибка базы данных.", nil
+			}
+			rowsAffected, at, err := h.store.DeleteAll(ctx)
+			if err != nil {
+				log.Printf("❌ Ошибка при удалении всех пожеланий: %v", err)
+				return "❌ Ошибка базы данных.", nil
+			}
+			h.audit(ctx, req.userID, models.AuditDeleteAll, wishIDs(wishes), wishes, nil)
+
+			h.feed.Publish(events.WishDeleted, map[string]bool{"all": true})
+			return fmt.Sprintf("🗑 В корзину перенесено %d пожеланий.", rowsAffected),
+				undoButtons("all:" + strconv.FormatInt(at.UnixMicro(), 10))
+		})
+}
+
+func (h *Handler) cmdRestore(ctx context.Context, req commandRequest) {
+	h.sendTelegramMessage(req.chatID, "📤 Отправьте файл <code>wishes.json</code> или архив <code>backup-….tar.gz</code>, чтобы восстановить пожелания.")
+}
+
+func (h *Handler) cmdAdmin(ctx context.Context, req commandRequest) {
+	h.adminCommand(ctx, req, req.args)
+}
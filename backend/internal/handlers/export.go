@@ -0,0 +1,96 @@
+// backend/internal/handlers/export.go
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"wedding-backend/internal/export"
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+)
+
+// exportFile выбирает пожелания и собирает выгрузку: для печатных форматов — только одобренные
+func (h *Handler) exportFile(ctx context.Context, format string) (export.File, error) {
+	filter := store.ListFilter{}
+	if export.Public(format) {
+		filter.Status = models.StatusApproved
+	}
+	wishes, err := h.store.List(ctx, filter)
+	if err != nil {
+		return export.File{}, err
+	}
+	return export.Render(format, wishes, export.Options{Location: h.cfg.Location})
+}
+
+// formatArg — один формат выгрузки из export.Formats
+func formatArg(args []string, req *commandRequest) bool {
+	if len(args) != 1 || !slices.Contains(export.Formats, strings.ToLower(args[0])) {
+		return false
+	}
+	req.text = strings.ToLower(args[0])
+	return true
+}
+
+// cmdExport — /export csv|json|html|md
+func (h *Handler) cmdExport(ctx context.Context, req commandRequest) {
+	file, err := h.exportFile(ctx, req.text)
+	if err != nil {
+		log.Printf("❌ Ошибка выгрузки: %v", err)
+		h.sendTelegramMessage(req.chatID, "❌ Не удалось собрать выгрузку.")
+		return
+	}
+	h.sendTelegramFile(req.chatID, file.Name, file.Data)
+}
+
+// GET /api/export?format=csv|json|html|md — та же выгрузка по HTTP.
+// Доступ по EXPORT_TOKEN: заголовок Authorization: Bearer <token> или ?token= для ссылки в браузере.
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		errorResponse(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.cfg.ExportToken == "" {
+		errorResponse(w, "Выгрузка отключена", http.StatusNotFound)
+		return
+	}
+
+	token := r.URL.Query().Get("token")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		token = bearer
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.cfg.ExportToken)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		errorResponse(w, "Неверный токен", http.StatusUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if !slices.Contains(export.Formats, format) {
+		errorResponse(w, "format должен быть одним из: "+strings.Join(export.Formats, ", "), http.StatusBadRequest)
+		return
+	}
+
+	file, err := h.exportFile(r.Context(), format)
+	if err != nil {
+		log.Printf("Database error: %v", err)
+		errorResponse(w, "Ошибка базы данных", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Cache-Control", "no-store")
+	// HTML открываем в браузере для печати, остальное скачиваем
+	if format != "html" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Name))
+	}
+	w.Write(file.Data)
+}
@@ -0,0 +1,221 @@
+// backend/internal/handlers/guests.go
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+)
+
+// utf8BOM — с ним Excel правильно открывает кириллицу в CSV
+const utf8BOM = "\ufeff"
+
+// maxPartySize — предел размера компании одного приглашения
+const maxPartySize = 10
+
+// importGuests создаёт и обновляет гостей из guests.csv.
+// Колонки (по заголовку, порядок любой): name, party_size, events, token.
+// Строка с известным token или именем обновляет гостя, иначе создаёт нового.
+func (h *Handler) importGuests(ctx context.Context, req commandRequest, data []byte) {
+	chatID := req.chatID
+	rows, err := readCSV(data)
+	if err != nil {
+		log.Printf("❌ Ошибка разбора CSV: %v", err)
+		h.sendTelegramMessage(chatID, "❌ Не удалось разобрать CSV.")
+		return
+	}
+	if len(rows) < 2 {
+		h.sendTelegramMessage(chatID, "❌ В файле нет строк с гостями.")
+		return
+	}
+
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		h.sendTelegramMessage(chatID, "❌ В заголовке CSV нет колонки <code>name</code>.")
+		return
+	}
+	field := func(row []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	existing, err := h.store.ListGuests(ctx)
+	if err != nil {
+		log.Printf("❌ Ошибка запроса к БД: %v", err)
+		h.sendTelegramMessage(chatID, "❌ Ошибка базы данных.")
+		return
+	}
+	byToken := map[string]models.Guest{}
+	byName := map[string]models.Guest{}
+	for _, g := range existing {
+		byToken[g.Token] = g
+		byName[strings.ToLower(g.Name)] = g
+	}
+
+	var guests []models.Guest
+	var problems []string
+	// seen — уже встреченные гости, seenNames — имена (как их ищет byName), чтобы одно
+	// новое имя дважды в файле не превратилось в двух гостей с двумя ссылками
+	seen := map[int]bool{}
+	seenNames := map[string]bool{}
+	created, updated := 0, 0
+	for n, row := range rows[1:] {
+		line := n + 2
+		name := field(row, "name")
+		if name == "" {
+			continue
+		}
+		if len(name) > 100 {
+			problems = append(problems, fmt.Sprintf("строка %d: имя длиннее 100 символов", line))
+			continue
+		}
+
+		partySize := 1
+		if v := field(row, "party_size"); v != "" {
+			partySize, err = strconv.Atoi(v)
+			if err != nil || partySize < 1 || partySize > maxPartySize {
+				problems = append(problems, fmt.Sprintf("строка %d: party_size должен быть от 1 до %d", line, maxPartySize))
+				continue
+			}
+		}
+
+		key := strings.ToLower(name)
+		guest, ok := byToken[field(row, "token")]
+		if !ok {
+			guest, ok = byName[key]
+		}
+		if seenNames[key] || (ok && seen[guest.ID]) {
+			problems = append(problems, fmt.Sprintf("строка %d: гость %s уже встречался выше", line, name))
+			continue
+		}
+		seenNames[key] = true
+		if ok {
+			seen[guest.ID] = true
+			updated++
+		} else {
+			guest = models.Guest{Token: newToken()}
+			created++
+		}
+
+		guest.Name = name
+		guest.PartySize = partySize
+		guest.Events = splitEvents(field(row, "events"))
+		guests = append(guests, guest)
+	}
+
+	if len(guests) == 0 {
+		h.sendTelegramMessage(chatID, "❌ Не найдено ни одного корректного гостя.\n\n"+html.EscapeString(strings.Join(problems, "\n")))
+		return
+	}
+
+	if err := h.store.SaveGuests(ctx, guests); err != nil {
+		log.Printf("❌ Ошибка импорта гостей: %v", err)
+		h.sendTelegramMessage(chatID, "❌ Ошибка базы данных, список гостей не изменён.")
+		return
+	}
+
+	h.audit(ctx, req.userID, models.AuditGuests, nil, nil, map[string]int{"created": created, "updated": updated})
+
+	report := fmt.Sprintf("✅ Гости загружены: новых %d, обновлено %d.", created, updated)
+	if len(problems) > 0 {
+		report += "\n\n⚠️ Пропущено:\n" + html.EscapeString(strings.Join(problems, "\n"))
+	}
+	h.sendTelegramMessage(chatID, report+"\n\nСсылки-приглашения: /guests")
+}
+
+// guestsCSV собирает список гостей с персональными ссылками, статусом RSVP и пожеланиями
+func (h *Handler) guestsCSV(ctx context.Context) ([]byte, int, error) {
+	guests, err := h.store.ListGuests(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	rsvps, err := h.store.ListRSVPs(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	wishes, err := h.store.List(ctx, store.ListFilter{})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rsvpByGuest := map[int]models.RSVP{}
+	for _, r := range rsvps {
+		if r.GuestID != nil {
+			rsvpByGuest[*r.GuestID] = r
+		}
+	}
+	wishCount := map[int]int{}
+	for _, w := range wishes {
+		if w.GuestID != nil {
+			wishCount[*w.GuestID]++
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(utf8BOM)
+	out := csv.NewWriter(&buf)
+	out.Write([]string{"name", "party_size", "events", "token", "invite_url", "rsvp", "plus_ones", "wishes"})
+	for _, g := range guests {
+		rsvp, plusOnes := "none", ""
+		if r, ok := rsvpByGuest[g.ID]; ok {
+			rsvp, plusOnes = r.Attending, strconv.Itoa(r.PlusOnes)
+		}
+		out.Write([]string{
+			g.Name,
+			strconv.Itoa(g.PartySize),
+			strings.Join(g.Events, "|"),
+			g.Token,
+			h.inviteURL(g.Token),
+			rsvp,
+			plusOnes,
+			strconv.Itoa(wishCount[g.ID]),
+		})
+	}
+	out.Flush()
+	return buf.Bytes(), len(guests), out.Error()
+}
+
+// inviteURL — персональная ссылка на сайт-приглашение
+func (h *Handler) inviteURL(token string) string {
+	return strings.TrimRight(h.cfg.InviteBaseURL, "/") + "/?invite=" + url.QueryEscape(token)
+}
+
+// readCSV читает CSV, присланный из Excel или Google Таблиц: с BOM
+// и разделителем «,» или «;» (определяется по заголовку)
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte(utf8BOM))
+
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	r := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		r.Comma = ';'
+	}
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	return r.ReadAll()
+}
+
+// splitEvents разбирает список событий приглашения: «ceremony|banquet» или «ceremony, banquet»
+func splitEvents(s string) []string {
+	events := []string{}
+	for _, e := range strings.FieldsFunc(s, func(r rune) bool { return r == '|' || r == ',' || r == ';' }) {
+		if e = strings.ToLower(strings.TrimSpace(e)); e != "" {
+			events = append(events, e)
+		}
+	}
+	return events
+}
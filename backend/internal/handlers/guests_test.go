@@ -0,0 +1,70 @@
+// backend/internal/handlers/guests_test.go
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"wedding-backend/internal/models"
+)
+
+// fakeBot подменяет Bot API и запоминает тексты отправленных сообщений
+func fakeBot(t *testing.T, h *Handler) *[]string {
+	t.Helper()
+	var mu sync.Mutex
+	var sent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/sendMessage") {
+			mu.Lock()
+			sent = append(sent, r.FormValue("text"))
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	t.Cleanup(srv.Close)
+	h.bot.Token = "test"
+	h.bot.BaseURL = srv.URL
+	return &sent
+}
+
+func TestImportGuestsDuplicates(t *testing.T) {
+	h, s := newTestHandler(t, Config{})
+	sent := fakeBot(t, h)
+	ctx := context.Background()
+	if err := s.SaveGuests(ctx, []models.Guest{{Name: "Аня", PartySize: 1, Token: "anya"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	csv := "name,party_size\nАня,2\nПетя,1\nпетя,3\nАНЯ,1\n"
+	h.importGuests(ctx, commandRequest{chatID: 1}, []byte(csv))
+
+	guests, err := s.ListGuests(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(guests) != 2 {
+		t.Fatalf("гостей %d: %+v", len(guests), guests)
+	}
+	// Повторные строки пропускаются, а не перезаписывают первые
+	party := map[string]int{"Аня": 2, "Петя": 1}
+	for _, g := range guests {
+		if g.PartySize != party[g.Name] {
+			t.Errorf("гость %s: party_size %d", g.Name, g.PartySize)
+		}
+	}
+
+	if len(*sent) != 1 {
+		t.Fatalf("сообщений %d", len(*sent))
+	}
+	report := (*sent)[0]
+	for _, want := range []string{"новых 1, обновлено 1", "строка 4", "строка 5"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("в отчёте нет %q: %s", want, report)
+		}
+	}
+}
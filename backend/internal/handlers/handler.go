@@ -0,0 +1,70 @@
+// backend/internal/handlers/handler.go
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"wedding-backend/internal/admins"
+	"wedding-backend/internal/backup"
+	"wedding-backend/internal/challenge"
+	"wedding-backend/internal/events"
+	"wedding-backend/internal/notify"
+	"wedding-backend/internal/outbox"
+	"wedding-backend/internal/spam"
+	"wedding-backend/internal/store"
+	"wedding-backend/internal/telegram"
+)
+
+// Config — настройки поведения обработчиков
+type Config struct {
+	// Moderation — новые пожелания ждут одобрения в Telegram, прежде чем появиться публично
+	Moderation bool
+	// InviteBaseURL — адрес сайта-приглашения для персональных ссылок гостей
+	InviteBaseURL string
+	// WebhookSecret — ожидаемый заголовок X-Telegram-Bot-Api-Secret-Token (пусто — не проверять)
+	WebhookSecret string
+	// Location — часовой пояс свадьбы для дат в выгрузках
+	Location *time.Location
+	// ExportToken — токен для GET /api/export (пусто — выгрузка по HTTP отключена)
+	ExportToken string
+	// TrashRetention — сколько удалённые пожелания хранятся в корзине
+	TrashRetention time.Duration
+	// Spam — проверки содержимого новых пожеланий
+	Spam spam.Pipeline
+	// Challenge — токены формы пожеланий (nil — POST /api/wish принимается без токена)
+	Challenge *challenge.Issuer
+}
+
+// Handler — HTTP-обработчики API и Telegram-вебхука
+type Handler struct {
+	store   store.Store
+	feed    *events.Broadcaster
+	outbox  *outbox.Worker
+	bot     *telegram.Client
+	admins  *admins.Registry
+	backups *backup.Manager
+	cfg     Config
+
+	confirms *confirmations
+	searches *searches
+	// botUsername — имя бота для команд вида /list@bot; заполняет RegisterCommands
+	botUsername string
+}
+
+// New создаёт обработчики поверх переданного хранилища, ленты событий, очереди уведомлений,
+// клиента Bot API, списка администраторов бота и резервных копий
+func New(s store.Store, feed *events.Broadcaster, outbox *outbox.Worker, bot *telegram.Client, admins *admins.Registry, backups *backup.Manager, cfg Config) *Handler {
+	return &Handler{store: s, feed: feed, outbox: outbox, bot: bot, admins: admins, backups: backups, cfg: cfg, confirms: newConfirmations(), searches: newSearches()}
+}
+
+// notify ставит уведомление в outbox; доставку и повторы берёт на себя воркер
+func (h *Handler) notify(msg notify.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.outbox.Enqueue(ctx, msg); err != nil {
+		log.Printf("❌ Ошибка постановки уведомления в очередь: %v", err)
+	}
+}
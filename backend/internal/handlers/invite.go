@@ -0,0 +1,75 @@
+// backend/internal/handlers/invite.go
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html"
+	"log"
+	"net/http"
+
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+)
+
+// errInviteNotFound — токен приглашения не найден
+var errInviteNotFound = errors.New("invite not found")
+
+// inviteResponse — ответ GET /api/invite/{token}
+type inviteResponse struct {
+	Name      string   `json:"name"`
+	PartySize int      `json:"party_size"`
+	Events    []string `json:"events"`
+}
+
+// GET /api/invite/{token} — данные персонального приглашения
+func (h *Handler) GetInvite(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		errorResponse(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	guest, err := h.guestByInvite(r.Context(), r.PathValue("token"))
+	if !inviteOK(w, err) {
+		return
+	}
+
+	events := guest.Events
+	if events == nil {
+		events = []string{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(inviteResponse{
+		Name:      html.EscapeString(guest.Name),
+		PartySize: guest.PartySize,
+		Events:    events,
+	})
+}
+
+// guestByInvite находит гостя по токену приглашения
+func (h *Handler) guestByInvite(ctx context.Context, token string) (models.Guest, error) {
+	if token == "" {
+		return models.Guest{}, errInviteNotFound
+	}
+	guest, err := h.store.GetGuestByToken(ctx, token)
+	if errors.Is(err, store.ErrNotFound) {
+		return guest, errInviteNotFound
+	}
+	return guest, err
+}
+
+// inviteOK отвечает клиенту ошибкой поиска приглашения; true — ошибки не было
+func inviteOK(w http.ResponseWriter, err error) bool {
+	if errors.Is(err, errInviteNotFound) {
+		errorResponse(w, "Приглашение не найдено", http.StatusNotFound)
+		return false
+	}
+	if err != nil {
+		log.Printf("Database error: %v", err)
+		errorResponse(w, "Ошибка базы данных", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,288 @@
+// backend/internal/handlers/listing.go
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+	"wedding-backend/internal/telegram"
+)
+
+const (
+	// telegramTextLimit — предел длины сообщения в Telegram
+	telegramTextLimit = 4096
+	// listPageSize — сколько пожеланий максимум на одной странице /list
+	listPageSize = 10
+	// listPageReserve — запас под заголовок и номер страницы
+	listPageReserve = 300
+	// maxLast — предел для /last N
+	maxLast = 100
+	// maxSearchRunes — предел длины запроса /search, как у q в GET /api/wishes
+	maxSearchRunes = 100
+	// searchTTL — сколько помнить запрос /search для кнопок листания
+	searchTTL = 24 * time.Hour
+)
+
+// listView — что листаем кнопками: весь список, последние N, результаты поиска или корзину.
+// Всё нужное для перерисовки хранится в callback_data, поэтому кнопки переживают перезапуск.
+// Исключение — запрос /search: в 64 байта callback_data он не помещается, и в кнопке
+// лежит только его ключ, а сам запрос сервер помнит searchTTL.
+type listView struct {
+	kind  string // list, last, search, trash
+	n     int
+	query string
+	key   string // ключ запроса в searches
+}
+
+// searches — запросы /search по короткому ключу
+type searches struct {
+	mu      sync.Mutex
+	queries map[string]savedSearch
+}
+
+type savedSearch struct {
+	query   string
+	expires time.Time
+}
+
+func newSearches() *searches {
+	return &searches{queries: make(map[string]savedSearch)}
+}
+
+// save запоминает запрос и возвращает его ключ; одинаковые запросы получают один ключ
+func (s *searches) save(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	key := base64.RawURLEncoding.EncodeToString(sum[:9])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for k, saved := range s.queries {
+		if now.After(saved.expires) {
+			delete(s.queries, k)
+		}
+	}
+	s.queries[key] = savedSearch{query: query, expires: now.Add(searchTTL)}
+	return key
+}
+
+// get возвращает запрос по ключу; false — забыт (прошло searchTTL или сервер перезапущен)
+func (s *searches) get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	saved, ok := s.queries[key]
+	if !ok || time.Now().After(saved.expires) {
+		return "", false
+	}
+	return saved.query, true
+}
+
+// data — callback_data кнопки перехода на страницу page
+func (v listView) data(page int) string {
+	switch v.kind {
+	case "last":
+		return fmt.Sprintf("page:last:%d:%d", v.n, page)
+	case "search":
+		return fmt.Sprintf("page:search:%d:%s", page, v.key)
+	case "trash":
+		return fmt.Sprintf("page:trash:%d", page)
+	}
+	return fmt.Sprintf("page:list:%d", page)
+}
+
+// parseListView разбирает аргументы кнопки: list:P, last:N:P, search:P:ключ, trash:P
+func parseListView(args []string) (listView, int, bool) {
+	if len(args) < 2 {
+		return listView{}, 0, false
+	}
+	v := listView{kind: args[0]}
+	var pageArg string
+	switch v.kind {
+	case "list", "trash":
+		pageArg = args[1]
+	case "last":
+		if len(args) != 3 {
+			return listView{}, 0, false
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 || n > maxLast {
+			return listView{}, 0, false
+		}
+		v.n, pageArg = n, args[2]
+	case "search":
+		if len(args) != 3 {
+			return listView{}, 0, false
+		}
+		pageArg, v.key = args[1], args[2]
+	default:
+		return listView{}, 0, false
+	}
+	page, err := strconv.Atoi(pageArg)
+	if err != nil || page <= 0 {
+		return listView{}, 0, false
+	}
+	return v, page, true
+}
+
+// loadView выбирает пожелания для списка и возвращает их с заголовком
+func (h *Handler) loadView(ctx context.Context, v listView) ([]models.Wish, string, error) {
+	switch v.kind {
+	case "last":
+		wishes, err := h.store.List(ctx, store.ListFilter{Limit: v.n})
+		return wishes, fmt.Sprintf("🕐 <b>Последние %d</b>", v.n), err
+	case "search":
+		// В БД текст хранится уже экранированным (см. cleanInput), ищем в том же виде
+		wishes, err := h.store.List(ctx, store.ListFilter{Query: html.EscapeString(v.query)})
+		return wishes, fmt.Sprintf("🔎 <b>Поиск «%s»</b>", html.EscapeString(v.query)), err
+	case "trash":
+		wishes, err := h.store.List(ctx, store.ListFilter{Trash: store.TrashOnly})
+		return wishes, "🗑 <b>Корзина</b>", err
+	}
+	wishes, err := h.store.List(ctx, store.ListFilter{})
+	return wishes, "📋 <b>Все пожелания</b>", err
+}
+
+// wishPages раскладывает пожелания по страницам: не больше listPageSize
+// и не длиннее лимита Telegram вместе с заголовком
+func wishPages(wishes []models.Wish) []string {
+	budget := telegramTextLimit - listPageReserve
+	var pages []string
+	var page strings.Builder
+	count := 0
+	for _, w := range wishes {
+		line := fmt.Sprintf("<b>№%d</b>%s %s: %s\n\n", w.ID, statusMark(w.Status), wishHTML(w.Name), wishHTML(w.Message))
+		if count > 0 && (count == listPageSize || utf8.RuneCountInString(page.String())+utf8.RuneCountInString(line) > budget) {
+			pages = append(pages, page.String())
+			page.Reset()
+			count = 0
+		}
+		page.WriteString(line)
+		count++
+	}
+	if count > 0 {
+		pages = append(pages, page.String())
+	}
+	return pages
+}
+
+// renderListPage собирает текст страницы page (с 1) и кнопки «Назад / Вперёд»
+func renderListPage(v listView, title string, wishes []models.Wish, page int) (string, [][]telegram.InlineButton) {
+	pages := wishPages(wishes)
+	if len(pages) == 0 {
+		switch v.kind {
+		case "search":
+			return title + "\n\nНичего не найдено.", nil
+		case "trash":
+			return title + "\n\nКорзина пуста.", nil
+		}
+		return title + "\n\nПока нет пожеланий.", nil
+	}
+	page = max(1, min(page, len(pages)))
+
+	text := fmt.Sprintf("%s: %d\n\n%s", title, len(wishes), pages[page-1])
+	if len(pages) == 1 {
+		return text, nil
+	}
+	text += fmt.Sprintf("Страница %d из %d", page, len(pages))
+
+	var row []telegram.InlineButton
+	if page > 1 {
+		row = append(row, telegram.InlineButton{Text: "« Назад", Data: v.data(page - 1)})
+	}
+	if page < len(pages) {
+		row = append(row, telegram.InlineButton{Text: "Вперёд »", Data: v.data(page + 1)})
+	}
+	return text, [][]telegram.InlineButton{row}
+}
+
+// showList отправляет страницу списка новым сообщением
+func (h *Handler) showList(ctx context.Context, chatID int64, v listView, page int) ([]models.Wish, bool) {
+	wishes, title, err := h.loadView(ctx, v)
+	if err != nil {
+		log.Printf("❌ Ошибка запроса к БД: %v", err)
+		h.sendTelegramMessage(chatID, "❌ Ошибка базы данных.")
+		return nil, false
+	}
+	text, buttons := renderListPage(v, title, wishes, page)
+	if _, err := h.sendTelegramButtons(chatID, text, buttons); err != nil {
+		log.Printf("❌ Ошибка отправки сообщения: %v", err)
+	}
+	return wishes, true
+}
+
+// onPage — кнопки «Назад / Вперёд» под списком: перерисовывают то же сообщение
+func (h *Handler) onPage(ctx context.Context, cq *telegram.CallbackQuery, args []string) {
+	v, page, ok := parseListView(args)
+	if !ok {
+		h.answerCallbackQuery(cq.ID, "Неизвестная кнопка")
+		return
+	}
+	if v.kind == "search" {
+		if v.query, ok = h.searches.get(v.key); !ok {
+			h.answerCallbackQuery(cq.ID, "Поиск устарел, повторите /search")
+			return
+		}
+	}
+	wishes, title, err := h.loadView(ctx, v)
+	if err != nil {
+		log.Printf("❌ Ошибка запроса к БД: %v", err)
+		h.answerCallbackQuery(cq.ID, "❌ Ошибка базы данных")
+		return
+	}
+	text, buttons := renderListPage(v, title, wishes, page)
+	h.editTelegramButtons(cq.Message.Chat.ID, cq.Message.MessageID, text, buttons)
+	h.answerCallbackQuery(cq.ID, "")
+}
+
+// === КОМАНДЫ ===
+
+// cmdList — /list [страница]; без номера страницы добавляет JSON-бэкап
+func (h *Handler) cmdList(ctx context.Context, req commandRequest) {
+	page := max(req.id, 1)
+	wishes, ok := h.showList(ctx, req.chatID, listView{kind: "list"}, page)
+	if !ok || req.id > 0 || len(wishes) == 0 {
+		return
+	}
+
+	// Создаём и отправляем JSON-файл
+	jsonData, err := json.MarshalIndent(wishes, "", "  ")
+	if err != nil {
+		log.Printf("❌ Ошибка сериализации JSON: %v", err)
+		return
+	}
+	h.sendTelegramFile(req.chatID, "wishes.json", jsonData)
+}
+
+// cmdLast — /last [N], по умолчанию 10
+func (h *Handler) cmdLast(ctx context.Context, req commandRequest) {
+	n := req.id
+	if n == 0 {
+		n = 10
+	}
+	if n > maxLast {
+		h.sendTelegramMessage(req.chatID, fmt.Sprintf("❌ Можно показать не больше %d последних.", maxLast))
+		return
+	}
+	h.showList(ctx, req.chatID, listView{kind: "last", n: n}, 1)
+}
+
+// cmdSearch — /search текст: поиск по имени и тексту пожелания
+func (h *Handler) cmdSearch(ctx context.Context, req commandRequest) {
+	if utf8.RuneCountInString(req.text) > maxSearchRunes {
+		h.sendTelegramMessage(req.chatID, fmt.Sprintf("❌ Запрос должен быть не длиннее %d символов.", maxSearchRunes))
+		return
+	}
+	h.showList(ctx, req.chatID, listView{kind: "search", query: req.text, key: h.searches.save(req.text)}, 1)
+}
@@ -0,0 +1,65 @@
+// backend/internal/handlers/listing_test.go
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"wedding-backend/internal/models"
+)
+
+func TestWishPagesEscaping(t *testing.T) {
+	// В БД текст уже экранирован cleanInput — в сообщении он не должен экранироваться второй раз
+	pages := wishPages([]models.Wish{{ID: 1, Name: cleanInput("Аня & Петя"), Message: cleanInput(`1 < 2, "горько"`)}})
+	if len(pages) != 1 {
+		t.Fatalf("страниц: %d", len(pages))
+	}
+	want := "Аня &amp; Петя: 1 &lt; 2, &#34;горько&#34;"
+	if !strings.Contains(pages[0], want) {
+		t.Errorf("страница %q не содержит %q", pages[0], want)
+	}
+}
+
+func TestSearchEscapedQuery(t *testing.T) {
+	h, s := newTestHandler(t, Config{})
+	ctx := context.Background()
+	if err := s.Create(ctx, &models.Wish{Name: "Аня", Message: cleanInput(`Кричим "горько" & танцуем`)}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, q := range []string{`"горько"`, "& танцуем"} {
+		wishes, title, err := h.loadView(ctx, listView{kind: "search", query: q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(wishes) != 1 {
+			t.Errorf("поиск %q: найдено %d", q, len(wishes))
+		}
+		if strings.Contains(title, `"`) {
+			t.Errorf("заголовок не экранирован: %q", title)
+		}
+	}
+}
+
+func TestSearchCallbackData(t *testing.T) {
+	h, _ := newTestHandler(t, Config{})
+	query := string([]rune(strings.Repeat("пожелание ", 10))[:maxSearchRunes])
+
+	v := listView{kind: "search", query: query, key: h.searches.save(query)}
+	data := v.data(999)
+	if len(data) > 64 {
+		t.Fatalf("callback_data длиной %d байт: %q", len(data), data)
+	}
+
+	parsed, page, ok := parseListView(strings.Split(data, ":")[1:])
+	if !ok || page != 999 {
+		t.Fatalf("не разобрали %q", data)
+	}
+	if got, ok := h.searches.get(parsed.key); !ok || got != query {
+		t.Errorf("по ключу %q получили %q, %t", parsed.key, got, ok)
+	}
+	if _, ok := h.searches.get("неизвестный"); ok {
+		t.Error("неизвестный ключ найден")
+	}
+}
@@ -0,0 +1,219 @@
+// backend/internal/handlers/restore.go
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"slices"
+	"strings"
+
+	"wedding-backend/internal/events"
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+	"wedding-backend/internal/telegram"
+)
+
+// maxRestoreIssues — сколько некорректных записей перечислять в отчёте
+const maxRestoreIssues = 10
+
+// restorePlan — сравнение wishes.json с текущей базой
+type restorePlan struct {
+	total int
+	// valid — корректные записи, уже очищенные так же, как в AddWish
+	valid []models.Wish
+	// changes — новые и изменённые записи (их пишет объединение)
+	changes []models.Wish
+	// previous — прежние версии изменённых записей, для журнала
+	previous  []models.Wish
+	added     int
+	changed   int
+	identical int
+	// obsolete — пожелания базы, которых нет в файле (удалятся при замене)
+	obsolete []models.Wish
+	invalid  []string
+}
+
+// planRestore проверяет каждую запись и сравнивает её с пожеланием с тем же ID
+func planRestore(wishes, current []models.Wish) restorePlan {
+	existing := make(map[int]models.Wish, len(current))
+	for _, w := range current {
+		existing[w.ID] = w
+	}
+
+	plan := restorePlan{total: len(wishes)}
+	seen := make(map[int]bool, len(wishes))
+	for i, w := range wishes {
+		w, err := validateRestored(w)
+		if err == nil && seen[w.ID] {
+			err = fmt.Errorf("ID %d повторяется", w.ID)
+		}
+		if err != nil {
+			plan.invalid = append(plan.invalid, fmt.Sprintf("запись %d: %v", i+1, err))
+			continue
+		}
+		seen[w.ID] = true
+		plan.valid = append(plan.valid, w)
+
+		old, ok := existing[w.ID]
+		switch {
+		case !ok:
+			plan.added++
+			plan.changes = append(plan.changes, w)
+		case sameWish(old, w):
+			plan.identical++
+		default:
+			plan.changed++
+			plan.changes = append(plan.changes, w)
+			plan.previous = append(plan.previous, old)
+		}
+	}
+
+	for _, w := range current {
+		if !seen[w.ID] {
+			plan.obsolete = append(plan.obsolete, w)
+		}
+	}
+	return plan
+}
+
+// validateRestored повторяет проверки AddWish. Текст в бэкапе уже экранирован,
+// поэтому перед cleanInput раскодируем его, чтобы не экранировать дважды.
+func validateRestored(w models.Wish) (models.Wish, error) {
+	if w.ID <= 0 {
+		return w, errors.New("нет ID")
+	}
+	w.Name = cleanInput(html.UnescapeString(w.Name))
+	w.Message = cleanInput(html.UnescapeString(w.Message))
+	if len(w.Name) == 0 || len(w.Name) > 100 {
+		return w, errors.New("имя должно быть от 1 до 100 символов")
+	}
+	if len(w.Message) == 0 {
+		return w, errors.New("пустое пожелание")
+	}
+	if w.Status == "" {
+		w.Status = models.StatusApproved
+	}
+	if !models.ValidStatus(w.Status) {
+		return w, fmt.Errorf("неизвестный статус %q", w.Status)
+	}
+	if w.CreatedAt.IsZero() {
+		return w, errors.New("нет даты")
+	}
+	return w, nil
+}
+
+func sameWish(a, b models.Wish) bool {
+	sameGuest := (a.GuestID == nil) == (b.GuestID == nil) && (a.GuestID == nil || *a.GuestID == *b.GuestID)
+	sameTrash := (a.DeletedAt == nil) == (b.DeletedAt == nil) && (a.DeletedAt == nil || a.DeletedAt.Equal(*b.DeletedAt))
+	return a.Name == b.Name && a.Message == b.Message && a.Status == b.Status &&
+		a.CreatedAt.Equal(b.CreatedAt) && sameGuest && sameTrash
+}
+
+// report — отчёт для предпросмотра
+func (p restorePlan) report() string {
+	var sb strings.Builder
+	sb.WriteString("📦 <b>Восстановление из wishes.json</b>\n")
+	fmt.Fprintf(&sb, "Записей в файле: %d\n\n", p.total)
+	fmt.Fprintf(&sb, "🆕 Новых: %d\n", p.added)
+	fmt.Fprintf(&sb, "✏️ Изменённых: %d\n", p.changed)
+	fmt.Fprintf(&sb, "🟰 Без изменений: %d\n", p.identical)
+	fmt.Fprintf(&sb, "⚠️ Некорректных: %d\n", len(p.invalid))
+	for i, issue := range p.invalid {
+		if i == maxRestoreIssues {
+			fmt.Fprintf(&sb, "   …и ещё %d\n", len(p.invalid)-maxRestoreIssues)
+			break
+		}
+		fmt.Fprintf(&sb, "   • %s\n", html.EscapeString(issue))
+	}
+	if len(p.invalid) > 0 {
+		sb.WriteString("Некорректные записи будут пропущены.\n")
+	}
+	return sb.String()
+}
+
+// question — отчёт с описанием вариантов для кнопок подтверждения
+func (p restorePlan) question() string {
+	var sb strings.Builder
+	sb.WriteString(p.report())
+	sb.WriteString("\n")
+	if len(p.changes) > 0 {
+		sb.WriteString("🔀 <b>Объединить</b> — добавить новые и обновить изменённые, остальное не трогать.\n")
+	}
+	fmt.Fprintf(&sb, "♻️ <b>Заменить</b> — оставить только пожелания из файла; будет удалено: %d "+
+		"(пожелания, присланные после этого отчёта, останутся).", len(p.obsolete))
+	return sb.String()
+}
+
+// restoreFromJSON проверяет wishes.json и присылает отчёт с кнопками
+// «Объединить / Заменить»; в базу ничего не пишется до подтверждения
+func (h *Handler) restoreFromJSON(ctx context.Context, req commandRequest, data []byte) {
+	var wishes []models.Wish
+	if err := json.Unmarshal(data, &wishes); err != nil {
+		log.Printf("❌ Ошибка парсинга JSON: %v", err)
+		h.sendTelegramMessage(req.chatID, "❌ Неверный формат JSON.")
+		return
+	}
+
+	if len(wishes) == 0 {
+		h.sendTelegramMessage(req.chatID, "❌ Файл пуст.")
+		return
+	}
+
+	current, err := h.store.List(ctx, store.ListFilter{Trash: store.TrashInclude})
+	if err != nil {
+		log.Printf("❌ Ошибка запроса к БД: %v", err)
+		h.sendTelegramMessage(req.chatID, "❌ Ошибка базы данных.")
+		return
+	}
+
+	plan := planRestore(wishes, current)
+	if len(plan.valid) == 0 {
+		h.sendTelegramMessage(req.chatID, plan.report()+"\n❌ В файле нет ни одной корректной записи.")
+		return
+	}
+	if len(plan.changes) == 0 && len(plan.obsolete) == 0 {
+		h.sendTelegramMessage(req.chatID, plan.report()+"\n✅ База уже совпадает с файлом.")
+		return
+	}
+
+	var choices []confirmChoice
+	if len(plan.changes) > 0 {
+		choices = append(choices, confirmChoice{key: "merge", text: "🔀 Объединить", run: func(ctx context.Context) (string, [][]telegram.InlineButton) {
+			return h.applyRestore(ctx, req.userID, plan, false,
+				fmt.Sprintf("✅ Объединено: новых %d, обновлено %d.", plan.added, plan.changed))
+		}})
+	}
+	choices = append(choices, confirmChoice{key: "replace", text: "♻️ Заменить", run: func(ctx context.Context) (string, [][]telegram.InlineButton) {
+		return h.applyRestore(ctx, req.userID, plan, true,
+			fmt.Sprintf("✅ База заменена: пожеланий %d, удалено %d.", len(plan.valid), len(plan.obsolete)))
+	}})
+	h.askChoice(req.chatID, req.userID, plan.question(), choices)
+}
+
+// applyRestore записывает проверенные пожелания (при объединении — только изменения)
+// и возвращает текст результата. Замена удаляет только те пожелания, что были в предпросмотре:
+// добавленные гостями после него сохранятся.
+func (h *Handler) applyRestore(ctx context.Context, actorID int64, plan restorePlan, replace bool, done string) (string, [][]telegram.InlineButton) {
+	wishes, before := plan.changes, plan.previous
+	ids := wishIDs(wishes)
+	var remove []int
+	if replace {
+		wishes, before = plan.valid, append(slices.Clone(plan.previous), plan.obsolete...)
+		remove = wishIDs(plan.obsolete)
+		ids = append(wishIDs(wishes), remove...)
+	}
+	restored, err := h.store.Restore(ctx, wishes, remove)
+	if err != nil {
+		log.Printf("❌ Ошибка восстановления: %v", err)
+		return "❌ Ошибка при восстановлении.", nil
+	}
+	log.Printf("♻️ Восстановлено пожеланий: %d (замена: %t)", restored, replace)
+	h.audit(ctx, actorID, models.AuditRestore, ids, before, wishes)
+
+	h.feed.Publish(events.WishRestored, map[string]int{"count": restored})
+	return done, nil
+}
@@ -0,0 +1,77 @@
+// backend/internal/handlers/restore_test.go
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+)
+
+func TestPlanRestore(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	current := []models.Wish{
+		{ID: 1, Name: "Аня", Message: "Счастья", Status: models.StatusApproved, CreatedAt: at},
+		{ID: 2, Name: "Петя", Message: "Любви", Status: models.StatusApproved, CreatedAt: at},
+		{ID: 3, Name: "Оля", Message: "Удачи", Status: models.StatusApproved, CreatedAt: at},
+	}
+	file := []models.Wish{
+		{ID: 1, Name: "Аня", Message: "Счастья", CreatedAt: at},
+		{ID: 2, Name: "Петя", Message: "Любви и тепла", CreatedAt: at},
+		{ID: 4, Name: "Вася", Message: "Горько!", CreatedAt: at},
+		{ID: 4, Name: "Вася", Message: "Ещё раз", CreatedAt: at},
+		{Name: "Без ID", Message: "Привет", CreatedAt: at},
+		{ID: 5, Name: "Без даты", Message: "Привет"},
+	}
+
+	plan := planRestore(file, current)
+	if plan.added != 1 || plan.changed != 1 || plan.identical != 1 {
+		t.Errorf("новых %d, изменённых %d, без изменений %d", plan.added, plan.changed, plan.identical)
+	}
+	if len(plan.valid) != 3 || len(plan.invalid) != 3 {
+		t.Errorf("корректных %d, некорректных %d: %v", len(plan.valid), len(plan.invalid), plan.invalid)
+	}
+	if len(plan.previous) != 1 || plan.previous[0].Message != "Любви" {
+		t.Errorf("прежние версии: %+v", plan.previous)
+	}
+	if len(plan.obsolete) != 1 || plan.obsolete[0].ID != 3 {
+		t.Errorf("лишние: %+v", plan.obsolete)
+	}
+}
+
+func TestRestoreReplaceKeepsNewWishes(t *testing.T) {
+	h, s := newTestHandler(t, Config{})
+	ctx := context.Background()
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	if _, err := s.Restore(ctx, []models.Wish{
+		{ID: 1, Name: "Аня", Message: "Счастья", CreatedAt: at},
+		{ID: 2, Name: "Петя", Message: "Любви", CreatedAt: at},
+	}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	current, err := s.List(ctx, store.ListFilter{Trash: store.TrashInclude})
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := planRestore([]models.Wish{{ID: 1, Name: "Аня", Message: "Счастья", CreatedAt: at}}, current)
+
+	// Гость присылает пожелание, пока админ думает над предпросмотром
+	fresh := models.Wish{Name: "Оля", Message: "Удачи"}
+	if err := s.Create(ctx, &fresh, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	h.applyRestore(ctx, 0, plan, true, "")
+
+	if _, err := s.Get(ctx, 2); err == nil {
+		t.Error("пожелание 2 из предпросмотра не удалено")
+	}
+	for _, id := range []int{1, fresh.ID} {
+		if _, err := s.Get(ctx, id); err != nil {
+			t.Errorf("пожелание %d пропало: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// backend/internal/handlers/rsvp.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"strings"
+
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/notify"
+	"wedding-backend/internal/store"
+)
+
+// maxPlusOnes — сколько спутников можно указать в одном ответе
+const maxPlusOnes = 5
+
+// rsvpRequest — тело POST /api/rsvp и PUT /api/rsvp/{token}
+type rsvpRequest struct {
+	Name          string `json:"name"`
+	Attending     string `json:"attending"`
+	PlusOnes      int    `json:"plus_ones"`
+	Dietary       string `json:"dietary"`
+	NeedsTransfer bool   `json:"needs_transfer"`
+	InviteToken   string `json:"invite_token"`
+}
+
+// POST /api/rsvp — ответить на приглашение. В ответе token для последующего изменения.
+// С invite_token ответ привязывается к гостю; повторная отправка меняет его ответ.
+func (h *Handler) CreateRSVP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		errorResponse(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req rsvpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	var guest *models.Guest
+	if req.InviteToken != "" {
+		g, err := h.guestByInvite(r.Context(), req.InviteToken)
+		if !inviteOK(w, err) {
+			return
+		}
+		guest = &g
+	}
+
+	rsvp, msg := validateRSVP(req, guest)
+	if msg != "" {
+		errorResponse(w, msg, http.StatusBadRequest)
+		return
+	}
+
+	if guest != nil {
+		existing, err := h.store.GetRSVPByGuest(r.Context(), guest.ID)
+		if err == nil {
+			rsvp.Token = existing.Token
+			h.saveRSVPUpdate(w, r, rsvp)
+			return
+		}
+		if !errors.Is(err, store.ErrNotFound) {
+			log.Printf("Database error: %v", err)
+			errorResponse(w, "Ошибка базы данных", http.StatusInternalServerError)
+			return
+		}
+		rsvp.GuestID = &guest.ID
+	}
+	rsvp.Token = newToken()
+
+	if err := h.store.CreateRSVP(r.Context(), &rsvp); err != nil {
+		log.Printf("Database error: %v", err)
+		errorResponse(w, "Ошибка базы данных", http.StatusInternalServerError)
+		return
+	}
+
+	h.notify(notify.Message{
+		Event: notify.EventRSVPCreated,
+		Text:  rsvpNotice("📝 <b>Новый ответ на приглашение</b>", rsvp),
+		Data:  publicRSVP(rsvpWithoutToken(rsvp)),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(publicRSVP(rsvp))
+}
+
+// GET/PUT /api/rsvp/{token} — получить или изменить свой ответ
+func (h *Handler) RSVPByToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "PUT" {
+		errorResponse(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	existing, err := h.store.GetRSVP(r.Context(), r.PathValue("token"))
+	if errors.Is(err, store.ErrNotFound) {
+		errorResponse(w, "Ответ не найден", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Database error: %v", err)
+		errorResponse(w, "Ошибка базы данных", http.StatusInternalServerError)
+		return
+	}
+
+	if r.Method == "GET" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(publicRSVP(existing))
+		return
+	}
+
+	var req rsvpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// Ответ, привязанный к гостю, сохраняет его имя и ограничения приглашения
+	var guest *models.Guest
+	if existing.GuestID != nil {
+		g, err := h.store.GetGuest(r.Context(), *existing.GuestID)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			log.Printf("Database error: %v", err)
+			errorResponse(w, "Ошибка базы данных", http.StatusInternalServerError)
+			return
+		}
+		if err == nil {
+			guest = &g
+		}
+	}
+
+	rsvp, msg := validateRSVP(req, guest)
+	if msg != "" {
+		errorResponse(w, msg, http.StatusBadRequest)
+		return
+	}
+	rsvp.Token = existing.Token
+	h.saveRSVPUpdate(w, r, rsvp)
+}
+
+// saveRSVPUpdate перезаписывает ответ по rsvp.Token и отвечает клиенту
+func (h *Handler) saveRSVPUpdate(w http.ResponseWriter, r *http.Request, rsvp models.RSVP) {
+	err := h.store.UpdateRSVP(r.Context(), &rsvp)
+	if errors.Is(err, store.ErrNotFound) {
+		errorResponse(w, "Ответ не найден", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Database error: %v", err)
+		errorResponse(w, "Ошибка базы данных", http.StatusInternalServerError)
+		return
+	}
+
+	h.notify(notify.Message{
+		Event: notify.EventRSVPUpdated,
+		Text:  rsvpNotice("✏️ <b>Гость изменил ответ</b>", rsvp),
+		Data:  publicRSVP(rsvpWithoutToken(rsvp)),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(publicRSVP(rsvp))
+}
+
+// validateRSVP чистит и проверяет ответ так же, как AddWish — пожелание.
+// Для гостя по приглашению имя и число спутников берутся из списка гостей.
+// Вторым значением возвращается текст ошибки для клиента.
+func validateRSVP(req rsvpRequest, guest *models.Guest) (models.RSVP, string) {
+	name := req.Name
+	limit := maxPlusOnes
+	if guest != nil {
+		name = guest.Name
+		limit = guest.PartySize - 1
+	}
+
+	rsvp := models.RSVP{
+		Name:          strings.TrimSpace(cleanInput(name)),
+		Attending:     req.Attending,
+		PlusOnes:      req.PlusOnes,
+		Dietary:       strings.TrimSpace(cleanInput(req.Dietary)),
+		NeedsTransfer: req.NeedsTransfer,
+	}
+
+	if len(rsvp.Name) == 0 || len(rsvp.Name) > 100 {
+		return rsvp, "Имя должно быть от 1 до 100 символов"
+	}
+	if !models.ValidAttending(rsvp.Attending) {
+		return rsvp, "attending должен быть yes, no или maybe"
+	}
+	if rsvp.PlusOnes < 0 || rsvp.PlusOnes > limit {
+		return rsvp, fmt.Sprintf("Можно взять не больше %d спутников", limit)
+	}
+	if len(rsvp.Dietary) > 300 {
+		return rsvp, "Пожелания по питанию — не длиннее 300 символов"
+	}
+
+	// Кто не придёт, тому не нужны ни спутники, ни трансфер
+	if rsvp.Attending == models.AttendingNo {
+		rsvp.PlusOnes = 0
+		rsvp.NeedsTransfer = false
+	}
+	return rsvp, ""
+}
+
+// publicRSVP экранирует ответ для отдачи наружу
+func publicRSVP(r models.RSVP) models.RSVP {
+	r.Name = html.EscapeString(r.Name)
+	r.Dietary = html.EscapeString(r.Dietary)
+	return r
+}
+
+// rsvpWithoutToken убирает токен, дающий право менять ответ, из данных для внешних получателей
+func rsvpWithoutToken(r models.RSVP) models.RSVP {
+	r.Token = ""
+	return r
+}
+
+// attendingLabel — вариант ответа по-русски
+func attendingLabel(attending string) string {
+	switch attending {
+	case models.AttendingYes:
+		return "✅ придёт"
+	case models.AttendingNo:
+		return "❌ не придёт"
+	}
+	return "🤔 пока не знает"
+}
+
+// rsvpNotice — текст уведомления об ответе гостя для Telegram
+func rsvpNotice(title string, r models.RSVP) string {
+	var b strings.Builder
+	b.WriteString(title + "\n\n")
+	b.WriteString(fmt.Sprintf("<b>Гость:</b> %s\n", html.EscapeString(r.Name)))
+	b.WriteString(fmt.Sprintf("<b>Ответ:</b> %s", attendingLabel(r.Attending)))
+	if r.PlusOnes > 0 {
+		b.WriteString(fmt.Sprintf(" (+%d)", r.PlusOnes))
+	}
+	if r.NeedsTransfer {
+		b.WriteString("\n🚌 Нужен трансфер")
+	}
+	if r.Dietary != "" {
+		b.WriteString(fmt.Sprintf("\n🍽 <i>%s</i>", html.EscapeString(r.Dietary)))
+	}
+	return b.String()
+}
+
+// rsvpSummary — сводка ответов для команды /rsvp
+func rsvpSummary(rsvps []models.RSVP) string {
+	if len(rsvps) == 0 {
+		return "📋 Пока никто не ответил на приглашение."
+	}
+
+	counts := map[string]int{}
+	heads := map[string]int{}
+	transfer := 0
+	var dietary []string
+	for _, r := range rsvps {
+		counts[r.Attending]++
+		heads[r.Attending] += 1 + r.PlusOnes
+		if r.NeedsTransfer {
+			transfer += 1 + r.PlusOnes
+		}
+		if r.Dietary != "" && r.Attending != models.AttendingNo {
+			dietary = append(dietary, fmt.Sprintf("• %s: %s", html.EscapeString(r.Name), html.EscapeString(r.Dietary)))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("📋 <b>Ответы на приглашение</b>: %d\n\n", len(rsvps)))
+	b.WriteString(fmt.Sprintf("✅ Придут: %d (человек с гостями: <b>%d</b>)\n", counts[models.AttendingYes], heads[models.AttendingYes]))
+	b.WriteString(fmt.Sprintf("🤔 Под вопросом: %d (до %d человек)\n", counts[models.AttendingMaybe], heads[models.AttendingMaybe]))
+	b.WriteString(fmt.Sprintf("❌ Не придут: %d\n", counts[models.AttendingNo]))
+	b.WriteString(fmt.Sprintf("\n👥 Максимум гостей: <b>%d</b>\n", heads[models.AttendingYes]+heads[models.AttendingMaybe]))
+	b.WriteString(fmt.Sprintf("🚌 Нужен трансфер: %d человек\n", transfer))
+	if len(dietary) > 0 {
+		b.WriteString("\n🍽 <b>Питание</b>:\n" + strings.Join(dietary, "\n"))
+	}
+	return b.String()
+}
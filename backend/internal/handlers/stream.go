@@ -0,0 +1,84 @@
+// backend/internal/handlers/stream.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"wedding-backend/internal/events"
+)
+
+// heartbeatInterval — как часто слать ping, чтобы прокси Render не рвал соединение
+const heartbeatInterval = 25 * time.Second
+
+// GET /api/wishes/stream — лента изменений пожеланий (Server-Sent Events).
+// События: created (пожелание), deleted ({"id"} или {"all"}), restored ({"count"})
+// и reset — клиенту нужно перечитать /api/wishes целиком.
+func (h *Handler) StreamWishes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		errorResponse(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		errorResponse(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	// Браузер присылает Last-Event-ID сам при переподключении;
+	// lastEventId в query — для первого подключения после перезагрузки страницы
+	lastIDStr := r.Header.Get("Last-Event-ID")
+	if lastIDStr == "" {
+		lastIDStr = r.URL.Query().Get("lastEventId")
+	}
+	lastID, _ := strconv.ParseUint(lastIDStr, 10, 64)
+
+	sub, missed, complete := h.feed.Subscribe(lastID)
+	defer h.feed.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprint(w, "retry: 3000\n\n")
+	if !complete {
+		fmt.Fprintf(w, "event: %s\ndata: {}\n\n", events.Reset)
+	}
+	for _, e := range missed {
+		writeEvent(w, e)
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case e, ok := <-sub.C:
+			if !ok {
+				// Отключены как медленный клиент — EventSource переподключится сам
+				return
+			}
+			writeEvent(w, e)
+			flusher.Flush()
+		}
+	}
+}
+
+// writeEvent пишет событие в формате text/event-stream; без id браузер сохранит прежний Last-Event-ID
+func writeEvent(w http.ResponseWriter, e events.Event) {
+	if e.ID != 0 {
+		fmt.Fprintf(w, "id: %d\n", e.ID)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, e.Data)
+}
@@ -1,50 +1,40 @@
-// internal/handlers/telegram_webhook.go
+// backend/internal/handlers/telegram.go
 package handlers
 
 import (
+	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
+	"html"
 	"io"
 	"log"
-	"mime/multipart"
 	"net/http"
-	"net/url"
-	"os"
-	"strconv"
 	"strings"
+	"time"
 
-	"wedding-backend/internal/database"
+	"wedding-backend/internal/backup"
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/notify"
+	"wedding-backend/internal/telegram"
 )
 
-// Wish — структура пожелания для JSON
-type Wish struct {
-	ID        int    `json:"id"`
-	Name      string `json:"name"`
-	Message   string `json:"message"`
-	CreatedAt string `json:"created_at"`
-}
-
-// Update — структура обновления от Telegram
-type Update struct {
-	UpdateID int `json:"update_id"`
-	Message  struct {
-		Chat struct {
-			ID int64 `json:"id"`
-		} `json:"chat"`
-		Text     string `json:"text"`
-		Document *struct {
-			FileID   string `json:"file_id"`
-			FileName string `json:"file_name"`
-		} `json:"document,omitempty"`
-	} `json:"message"`
-}
+// maxUploadSize — предел размера файла, присланного боту
+const maxUploadSize = 5 << 20
 
-func HandleWebhook(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
 		return
 	}
 
-	var update Update
+	// Telegram присылает секрет, переданный в setWebhook; чужие запросы даже не разбираем
+	if !h.validWebhookSecret(r) {
+		log.Printf("⚠️ Запрос к вебхуку без верного секрета от %s", r.RemoteAddr)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var update telegram.Update
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		log.Printf("❌ Ошибка чтения тела запроса: %v", err)
@@ -56,301 +46,216 @@ func HandleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Проверяем, что команда от владельца
-	ownerIDStr := os.Getenv("CHAT_ID")
-	if ownerIDStr == "" {
-		log.Println("⚠️ CHAT_ID не задан")
+	h.HandleUpdate(r.Context(), update)
+}
+
+// HandleUpdate обрабатывает обновление от Telegram — из вебхука или из getUpdates
+func (h *Handler) HandleUpdate(ctx context.Context, update telegram.Update) {
+	if cq := update.CallbackQuery; cq != nil {
+		if cq.Message == nil {
+			return
+		}
+		role := h.roleOf(ctx, cq.From.ID)
+		if role == "" {
+			log.Printf("Игнорируем нажатие кнопки от чужого ID: %d", cq.From.ID)
+			return
+		}
+		h.handleCallback(ctx, cq, role)
 		return
 	}
-	ownerID, err := strconv.ParseInt(ownerIDStr, 10, 64)
-	if err != nil {
-		log.Println("⚠️ CHAT_ID должен быть числом")
+
+	msg := update.Message
+	if msg == nil {
 		return
 	}
-
-	if update.Message.Chat.ID != ownerID {
-		log.Printf("Игнорируем команду от чужого ID: %d", update.Message.Chat.ID)
+	// Команду подаёт пользователь; в групповом чате администраторов отвечаем в группу
+	chatID, userID := msg.Chat.ID, msg.Chat.ID
+	if msg.From != nil {
+		userID = msg.From.ID
+	}
+	role := h.roleOf(ctx, userID)
+	if role == "" {
+		log.Printf("Игнорируем команду от чужого ID: %d", userID)
 		return
 	}
 
-	text := strings.TrimSpace(update.Message.Text)
-
-	// === ОБРАБОТКА КОМАНД ===
-	if text == "/start" {
-		sendTelegramMessage(ownerID, "Привет! 🌸\n\nДоступные команды:\n\n"+
-			"/list — все пожелания + JSON-бэкап\n"+
-			"/delete 5 — удалить по ID\n"+
-			"/delete_all — удалить всё (с подтверждением)\n"+
-			"/restore — восстановить из файла wishes.json")
-
-	} else if text == "/list" {
-		rows, err := database.DB.Query("SELECT id, name, message, created_at FROM wishes ORDER BY created_at DESC")
-		if err != nil {
-			log.Printf("❌ Ошибка запроса к БД: %v", err)
-			sendTelegramMessage(ownerID, "❌ Ошибка базы данных.")
-			return
-		}
-		defer rows.Close()
-
-		var wishes []Wish
-		var response strings.Builder
-		response.WriteString("📋 <b>Все пожелания</b>:\n\n")
-		count := 0
-
-		for rows.Next() {
-			var w Wish
-			if err := rows.Scan(&w.ID, &w.Name, &w.Message, &w.CreatedAt); err != nil {
-				log.Printf("❌ Ошибка сканирования строки: %v", err)
-				continue
-			}
-			w.Name = htmlEscape(w.Name)
-			w.Message = htmlEscape(w.Message)
-
-			wishes = append(wishes, w)
-			response.WriteString(fmt.Sprintf("<b>№%d</b> %s: %s\n\n", w.ID, w.Name, w.Message))
-			count++
-		}
-
-		if count == 0 {
-			response.WriteString("Пока нет пожеланий.")
-		}
-
-		// Отправляем список
-		sendTelegramMessage(ownerID, response.String())
-
-		// Создаём и отправляем JSON-файл
-		jsonData, err := json.MarshalIndent(wishes, "", "  ")
-		if err != nil {
-			log.Printf("❌ Ошибка сериализации JSON: %v", err)
+	// Загруженный файл обрабатываем по имени: wishes.json, guests.csv
+	if doc := msg.Document; doc != nil {
+		if !models.RoleAllows(role, models.RoleOwner) {
+			h.sendTelegramMessage(chatID, fmt.Sprintf("⛔️ Недостаточно прав: нужна роль %s.", roleLabel(models.RoleOwner)))
 			return
 		}
-		sendTelegramFile(ownerID, "wishes.json", jsonData)
-
-	} else if text == "/delete_all" {
-		sendTelegramMessage(ownerID, "⚠️ Вы уверены?\n\nИспользуйте:\n/delete_all_confirm — подтвердить\n/abort — отмена")
+		h.handleDocument(ctx, commandRequest{chatID: chatID, userID: userID, role: role}, doc.FileID, doc.FileName)
+		return
+	}
 
-	} else if text == "/delete_all_confirm" {
-		res, err := database.DB.Exec("DELETE FROM wishes")
-		if err != nil {
-			log.Printf("❌ Ошибка при удалении всех пожеланий: %v", err)
-			sendTelegramMessage(ownerID, "❌ Ошибка базы данных.")
-			return
+	name, args, ok := parseCommand(msg.Text, h.botUsername)
+	if !ok {
+		// В общем чате не отвечаем на обычные сообщения и команды другим ботам
+		if chatID == userID {
+			h.sendTelegramMessage(chatID, "Неизвестная команда. Используй: /start")
 		}
+		return
+	}
+	h.runCommand(ctx, commandRequest{chatID: chatID, userID: userID, role: role}, name, args)
+}
 
-		rowsAffected, _ := res.RowsAffected()
-		sendTelegramMessage(ownerID, fmt.Sprintf("✅ Удалено %d пожеланий.", rowsAffected))
+// === ВСПОМОГАТЕЛЬНЫЕ ФУНКЦИИ ===
 
-	} else if text == "/abort" {
-		sendTelegramMessage(ownerID, "✅ Операция отменена.")
+// validWebhookSecret сравнивает X-Telegram-Bot-Api-Secret-Token с настроенным секретом
+func (h *Handler) validWebhookSecret(r *http.Request) bool {
+	if h.cfg.WebhookSecret == "" {
+		return true
+	}
+	got := r.Header.Get("X-Telegram-Bot-Api-Secret-Token")
+	return subtle.ConstantTimeCompare([]byte(got), []byte(h.cfg.WebhookSecret)) == 1
+}
 
-	} else if text == "/restore" {
-		sendTelegramMessage(ownerID, "📤 Отправьте файл <code>wishes.json</code>, чтобы восстановить пожелания.")
+// statusMark — пометка статуса модерации в /list (одобренные без пометки)
+func statusMark(status string) string {
+	switch status {
+	case models.StatusPending:
+		return " ⏳"
+	case models.StatusRejected:
+		return " 🚫"
+	}
+	return ""
+}
 
-	} else if len(text) > 8 && text[:8] == "/delete " {
-		var id int
-		_, err := fmt.Sscanf(text, "/delete %d", &id)
-		if err != nil || id <= 0 {
-			sendTelegramMessage(ownerID, "❌ Укажи корректный ID: /delete 5")
-			return
-		}
+// failedSummary — список недоставленных уведомлений для команды /failed
+func failedSummary(entries []models.OutboxEntry) string {
+	if len(entries) == 0 {
+		return "✅ Недоставленных уведомлений нет."
+	}
 
-		res, err := database.DB.Exec("DELETE FROM wishes WHERE id = $1", id)
-		if err != nil {
-			log.Printf("❌ Ошибка при удалении: %v", err)
-			sendTelegramMessage(ownerID, "❌ Ошибка базы данных.")
-			return
-		}
+	var b strings.Builder
+	b.WriteString("💀 <b>Недоставленные уведомления</b>:\n\n")
+	for _, e := range entries {
+		var msg notify.Message
+		json.Unmarshal(e.Payload, &msg)
+		title, _, _ := strings.Cut(notify.PlainText(msg.Text), "\n")
 
-		rowsAffected, _ := res.RowsAffected()
-		if rowsAffected > 0 {
-			sendTelegramMessage(ownerID, fmt.Sprintf("✅ Пожелание №%d удалено.", id))
-		} else {
-			sendTelegramMessage(ownerID, "❌ Пожелание с таким ID не найдено.")
+		b.WriteString(fmt.Sprintf("<b>#%d</b> %s, попыток: %d, %s\n", e.ID, e.Channel, e.Attempts, e.CreatedAt.Format("02.01 15:04")))
+		if title != "" {
+			b.WriteString(html.EscapeString(title) + "\n")
 		}
-
-	} else if update.Message.Document != nil && strings.ToLower(update.Message.Document.FileName) == "wishes.json" {
-		// Автоматическое восстановление из файла
-		restoreFromJSON(ownerID, update.Message.Document.FileID)
-	} else {
-		sendTelegramMessage(ownerID, "Неизвестная команда. Используй: /start")
+		b.WriteString(fmt.Sprintf("<i>%s</i>\n\n", html.EscapeString(truncate(e.LastError, 200))))
 	}
+	b.WriteString("Повторить: /retry ID")
+	return b.String()
 }
 
-// === ВСПОМОГАТЕЛЬНЫЕ ФУНКЦИИ ===
-
-// Экранируем HTML
-func htmlEscape(s string) string {
-	s = strings.ReplaceAll(s, "&", "&amp;")
-	s = strings.ReplaceAll(s, "<", "<")
-	s = strings.ReplaceAll(s, ">", ">")
-	s = strings.ReplaceAll(s, "\"", "&quot;")
+// truncate обрезает строку до n символов
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n]) + "…"
+	}
 	return s
 }
 
-// Отправка текстового сообщения
-func sendTelegramMessage(chatID int64, text string) {
-	token := os.Getenv("TG_TOKEN")
-	if token == "" {
-		log.Println("⚠️ TG_TOKEN не задан")
-		return
-	}
+// botTimeout — сколько ждать ответа Bot API на команду из чата
+const botTimeout = 30 * time.Second
 
-	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", token)
-	data := url.Values{}
-	data.Set("chat_id", strconv.FormatInt(chatID, 10))
-	data.Set("text", text)
-	data.Set("parse_mode", "HTML")
+// Отправка текстового сообщения
+func (h *Handler) sendTelegramMessage(chatID int64, text string) {
+	ctx, cancel := context.WithTimeout(context.Background(), botTimeout)
+	defer cancel()
 
-	resp, err := http.Post(apiURL, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
-	if err != nil {
+	if _, err := h.bot.SendMessage(ctx, chatID, text, nil); err != nil {
 		log.Printf("❌ Ошибка отправки сообщения: %v", err)
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		log.Printf("❌ Telegram API error: %s", body)
 	}
 }
 
-// Отправка файла
-func sendTelegramFile(chatID int64, fileName string, fileData []byte) {
-	token := os.Getenv("TG_TOKEN")
-	if token == "" {
-		log.Println("⚠️ TG_TOKEN не задан")
-		return
-	}
+// Отправка сообщения с inline-кнопками; возвращает message_id отправленного сообщения
+func (h *Handler) sendTelegramButtons(chatID int64, text string, buttons [][]telegram.InlineButton) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), botTimeout)
+	defer cancel()
 
-	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendDocument", token)
-	body := new(strings.Builder)
-	writer := multipart.NewWriter(body)
+	msg, err := h.bot.SendMessage(ctx, chatID, text, buttons)
+	return msg.MessageID, err
+}
 
-	_ = writer.WriteField("chat_id", strconv.FormatInt(chatID, 10))
+// Ответ на нажатие inline-кнопки (всплывающее уведомление)
+func (h *Handler) answerCallbackQuery(callbackID, text string) {
+	ctx, cancel := context.WithTimeout(context.Background(), botTimeout)
+	defer cancel()
 
-	fileWriter, err := writer.CreateFormFile("document", fileName)
-	if err != nil {
-		log.Printf("❌ Ошибка создания файла: %v", err)
-		return
+	if err := h.bot.AnswerCallbackQuery(ctx, callbackID, text); err != nil {
+		log.Printf("❌ Ошибка ответа на callback: %v", err)
 	}
-	_, _ = fileWriter.Write(fileData)
-
-	contentType := writer.FormDataContentType()
-	writer.Close()
+}
 
-	resp, err := http.Post(apiURL, contentType, strings.NewReader(body.String()))
-	if err != nil {
-		log.Printf("❌ Ошибка отправки файла: %v", err)
-		return
-	}
-	defer resp.Body.Close()
+// Редактирование отправленного сообщения (кнопки при этом убираются)
+func (h *Handler) editTelegramMessage(chatID int64, messageID int, text string) {
+	ctx, cancel := context.WithTimeout(context.Background(), botTimeout)
+	defer cancel()
 
-	if resp.StatusCode != 200 {
-		bodyResp, _ := io.ReadAll(resp.Body)
-		log.Printf("❌ Ошибка Telegram API при отправке файла: %s", bodyResp)
+	if err := h.bot.EditMessageText(ctx, chatID, messageID, text, nil); err != nil {
+		log.Printf("❌ Ошибка редактирования сообщения: %v", err)
 	}
 }
 
-// Получение URL файла от Telegram
-func getTelegramFileURL(fileID string) (string, error) {
-	token := os.Getenv("TG_TOKEN")
-	if token == "" {
-		return "", fmt.Errorf("TG_TOKEN not set")
-	}
-
-	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/getFile", token)
-	data := url.Values{}
-	data.Set("file_id", fileID)
-
-	resp, err := http.Post(apiURL, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
+// Редактирование сообщения с заменой inline-кнопок (nil — убрать)
+func (h *Handler) editTelegramButtons(chatID int64, messageID int, text string, buttons [][]telegram.InlineButton) {
+	ctx, cancel := context.WithTimeout(context.Background(), botTimeout)
+	defer cancel()
 
-	var result struct {
-		Ok     bool `json:"ok"`
-		Result struct {
-			FilePath string `json:"file_path"`
-		} `json:"result"`
+	if err := h.bot.EditMessageText(ctx, chatID, messageID, text, buttons); err != nil {
+		log.Printf("❌ Ошибка редактирования сообщения: %v", err)
 	}
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
-	}
+// Отправка файла
+func (h *Handler) sendTelegramFile(chatID int64, fileName string, fileData []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), botTimeout)
+	defer cancel()
 
-	if !result.Ok {
-		return "", fmt.Errorf("telegram API error")
+	if _, err := h.bot.SendDocument(ctx, chatID, fileName, fileData, ""); err != nil {
+		log.Printf("❌ Ошибка отправки файла: %v", err)
 	}
-
-	return fmt.Sprintf("https://api.telegram.org/file/bot%s/%s", token, result.Result.FilePath), nil
 }
 
-// Восстановление из JSON-файла
-func restoreFromJSON(chatID int64, fileID string) {
-	fileURL, err := getTelegramFileURL(fileID)
-	if err != nil {
-		log.Printf("❌ Ошибка получения URL файла: %v", err)
-		sendTelegramMessage(chatID, "❌ Не удалось получить файл.")
-		return
-	}
+// Скачивание файла, присланного боту
+func (h *Handler) downloadTelegramFile(fileID string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), botTimeout)
+	defer cancel()
 
-	resp, err := http.Get(fileURL)
+	file, err := h.bot.GetFile(ctx, fileID)
 	if err != nil {
-		log.Printf("❌ Ошибка загрузки файла: %v", err)
-		sendTelegramMessage(chatID, "❌ Не удалось загрузить файл.")
-		return
+		return nil, err
 	}
-	defer resp.Body.Close()
+	return h.bot.DownloadFile(ctx, file.FilePath, maxUploadSize)
+}
 
-	if resp.StatusCode != 200 {
-		sendTelegramMessage(chatID, "❌ Ошибка при скачивании файла.")
-		return
-	}
+// documentHandler обрабатывает содержимое загруженного файла
+type documentHandler func(ctx context.Context, req commandRequest, data []byte)
 
-	var wishes []Wish
-	if err := json.NewDecoder(resp.Body).Decode(&wishes); err != nil {
-		log.Printf("❌ Ошибка парсинга JSON: %v", err)
-		sendTelegramMessage(chatID, "❌ Неверный формат JSON.")
-		return
+// documentHandlers — имя загруженного файла → обработчик
+func (h *Handler) documentHandlers() map[string]documentHandler {
+	return map[string]documentHandler{
+		"wishes.json": h.restoreFromJSON,
+		"guests.csv":  h.importGuests,
 	}
+}
 
-	if len(wishes) == 0 {
-		sendTelegramMessage(chatID, "❌ Файл пуст.")
-		return
+// handleDocument скачивает файл и передаёт его обработчику по имени файла;
+// архивы резервных копий узнаём по шаблону имени backup-….tar.gz
+func (h *Handler) handleDocument(ctx context.Context, req commandRequest, fileID, fileName string) {
+	chatID := req.chatID
+	handle, ok := h.documentHandlers()[strings.ToLower(fileName)]
+	if !ok && backup.IsArchiveName(fileName) {
+		handle, ok = h.restoreFromBackupFile, true
 	}
-
-	tx, err := database.DB.Begin()
-	if err != nil {
-		sendTelegramMessage(chatID, "❌ Ошибка базы данных.")
+	if !ok {
+		h.sendTelegramMessage(chatID, "❌ Не знаю, что делать с этим файлом. Поддерживаются <code>wishes.json</code>, <code>guests.csv</code> и архивы <code>backup-….tar.gz</code>.")
 		return
 	}
-	defer tx.Rollback()
 
-	stmt, err := tx.Prepare("INSERT INTO wishes (id, name, message, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT (id) DO UPDATE SET name = $2, message = $3, created_at = $4")
+	data, err := h.downloadTelegramFile(fileID)
 	if err != nil {
-		sendTelegramMessage(chatID, "❌ Ошибка подготовки запроса.")
-		return
-	}
-	defer stmt.Close()
-
-	restored := 0
-	for _, w := range wishes {
-		if w.Name == "" || w.Message == "" {
-			continue
-		}
-		_, err := stmt.Exec(w.ID, w.Name, w.Message, w.CreatedAt)
-		if err != nil {
-			log.Printf("❌ Ошибка вставки ID=%d: %v", w.ID, err)
-			continue
-		}
-		restored++
-	}
-
-	if err := tx.Commit(); err != nil {
-		sendTelegramMessage(chatID, "❌ Ошибка при восстановлении.")
+		log.Printf("❌ Ошибка загрузки файла: %v", err)
+		h.sendTelegramMessage(chatID, "❌ Не удалось загрузить файл.")
 		return
 	}
-
-	sendTelegramMessage(chatID, fmt.Sprintf("✅ Восстановлено %d пожеланий.", restored))
+	handle(ctx, req, data)
 }
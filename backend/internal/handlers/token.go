@@ -0,0 +1,16 @@
+// backend/internal/handlers/token.go
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+)
+
+// newToken возвращает случайный токен для ссылок гостей (128 бит, URL-safe)
+func newToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand не должен отказывать
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
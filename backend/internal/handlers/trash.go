@@ -0,0 +1,103 @@
+// backend/internal/handlers/trash.go
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"wedding-backend/internal/events"
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+	"wedding-backend/internal/telegram"
+)
+
+// undoButtons — кнопка «Отменить» под сообщением об удалении: undo:<id> или undo:all:<время в мкс>
+func undoButtons(arg string) [][]telegram.InlineButton {
+	return [][]telegram.InlineButton{{{Text: "↩️ Отменить", Data: "undo:" + arg}}}
+}
+
+// cmdTrash — /trash: пожелания в корзине по страницам
+func (h *Handler) cmdTrash(ctx context.Context, req commandRequest) {
+	wishes, ok := h.showList(ctx, req.chatID, listView{kind: "trash"}, 1)
+	if ok && len(wishes) > 0 && h.cfg.TrashRetention > 0 {
+		h.sendTelegramMessage(req.chatID, fmt.Sprintf("ℹ️ Вернуть: /undelete ID. Через %d дн. после удаления пожелания стираются навсегда.",
+			int(h.cfg.TrashRetention.Hours()/24)))
+	}
+}
+
+// cmdUndelete — /undelete ID
+func (h *Handler) cmdUndelete(ctx context.Context, req commandRequest) {
+	text, err := h.undelete(ctx, req.userID, req.id)
+	if err != nil {
+		log.Printf("❌ Ошибка восстановления из корзины: %v", err)
+	}
+	h.sendTelegramMessage(req.chatID, text)
+}
+
+// undelete возвращает пожелание из корзины и текст ответа
+func (h *Handler) undelete(ctx context.Context, actorID int64, id int) (string, error) {
+	wish, err := h.store.Undelete(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return fmt.Sprintf("❌ Пожелания №%d нет в корзине.", id), nil
+	}
+	if err != nil {
+		return "❌ Ошибка базы данных.", err
+	}
+	h.audit(ctx, actorID, models.AuditUndelete, []int{id}, nil, wish)
+
+	if wish.Status == models.StatusApproved {
+		h.feed.Publish(events.WishCreated, publicWish(wish))
+	}
+	return fmt.Sprintf("↩️ Пожелание №%d возвращено из корзины.", id), nil
+}
+
+// onUndo — кнопка «Отменить» под сообщением об удалении
+func (h *Handler) onUndo(ctx context.Context, cq *telegram.CallbackQuery, args []string) {
+	chatID, messageID := cq.Message.Chat.ID, cq.Message.MessageID
+
+	// undo:all:<мкс> — отмена /delete_all, она доступна только владельцу, как и сама команда
+	if len(args) == 2 && args[0] == "all" {
+		micros, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			h.answerCallbackQuery(cq.ID, "Неизвестная кнопка")
+			return
+		}
+		if !models.RoleAllows(h.roleOf(ctx, cq.From.ID), models.RoleOwner) {
+			h.answerCallbackQuery(cq.ID, "⛔️ Недостаточно прав")
+			return
+		}
+		n, err := h.store.UndeleteAll(ctx, time.UnixMicro(micros))
+		if err != nil {
+			log.Printf("❌ Ошибка восстановления из корзины: %v", err)
+			h.answerCallbackQuery(cq.ID, "❌ Ошибка базы данных")
+			return
+		}
+		h.audit(ctx, cq.From.ID, models.AuditUndeleteAll, nil, nil, map[string]int64{"count": n})
+		h.feed.Publish(events.WishRestored, map[string]int64{"count": n})
+		h.answerCallbackQuery(cq.ID, "Отменено")
+		h.editTelegramMessage(chatID, messageID, fmt.Sprintf("↩️ Из корзины возвращено %d пожеланий.", n))
+		return
+	}
+
+	if len(args) != 1 {
+		h.answerCallbackQuery(cq.ID, "Неизвестная кнопка")
+		return
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		h.answerCallbackQuery(cq.ID, "Неизвестная кнопка")
+		return
+	}
+	text, err := h.undelete(ctx, cq.From.ID, id)
+	if err != nil {
+		log.Printf("❌ Ошибка восстановления из корзины: %v", err)
+		h.answerCallbackQuery(cq.ID, "❌ Ошибка базы данных")
+		return
+	}
+	h.answerCallbackQuery(cq.ID, "")
+	h.editTelegramMessage(chatID, messageID, text)
+}
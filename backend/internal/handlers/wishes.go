@@ -6,11 +6,17 @@ import (
 	"html"
 	"log"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
-	"wedding-backend/internal/database"
+	"wedding-backend/internal/events"
 	"wedding-backend/internal/models"
+	"wedding-backend/internal/notify"
+	"wedding-backend/internal/spam"
+	"wedding-backend/internal/store"
 	"wedding-backend/internal/telegram"
 )
 
@@ -25,41 +31,165 @@ func errorResponse(w http.ResponseWriter, message string, statusCode int) {
 	json.NewEncoder(w).Encode(map[string]string{"error": message})
 }
 
-// GET /api/wishes — получить все пожелания
-func GetWishes(w http.ResponseWriter, r *http.Request) {
+// Ограничения размера страницы для GET /api/wishes
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// wishesPage — ответ GET /api/wishes с параметрами пагинации
+type wishesPage struct {
+	Items      []models.Wish `json:"items"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+	PrevCursor string        `json:"prev_cursor,omitempty"`
+}
+
+// GET /api/wishes — получить пожелания.
+// Без параметров отдаёт голый массив всех пожеланий (для бегущей строки в App.jsx).
+// С любым из limit, before, after, since, q — страницу в конверте wishesPage.
+// next_cursor (есть, только если записи ещё остались) передаётся в тот же параметр,
+// что и текущий курсор, prev_cursor — в противоположный (before ↔ after).
+func (h *Handler) GetWishes(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
 		errorResponse(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	rows, err := database.DB.Query("SELECT id, name, message, created_at FROM wishes ORDER BY created_at DESC")
+	params := r.URL.Query()
+	paged := false
+	for _, key := range []string{"limit", "before", "after", "since", "q"} {
+		if params.Has(key) {
+			paged = true
+		}
+	}
+
+	var filter store.ListFilter
+	if paged {
+		var err error
+		filter, err = parseListFilter(params)
+		if err != nil {
+			errorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Берём на одну запись больше, чтобы понять, есть ли следующая страница
+		filter.Limit++
+	}
+	// Публично видны только одобренные пожелания
+	filter.Status = models.StatusApproved
+
+	wishes, err := h.store.List(r.Context(), filter)
 	if err != nil {
 		errorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	defer rows.Close()
 
-	var wishes []models.Wish
-	for rows.Next() {
-		var wish models.Wish
-		if err := rows.Scan(&wish.ID, &wish.Name, &wish.Message, &wish.CreatedAt); err != nil {
-			errorResponse(w, err.Error(), http.StatusInternalServerError)
-			return
+	// Экранируем HTML при выводе
+	for i := range wishes {
+		wishes[i] = publicWish(wishes[i])
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !paged {
+		json.NewEncoder(w).Encode(wishes)
+		return
+	}
+
+	page := wishesPage{Items: wishes}
+	limit := filter.Limit - 1
+	hasMore := len(wishes) > limit
+	if hasMore {
+		if filter.After != nil {
+			// Лишняя запись — самая новая, дальняя от курсора
+			page.Items = wishes[1:]
+		} else {
+			page.Items = wishes[:limit]
+		}
+	}
+	if page.Items == nil {
+		page.Items = []models.Wish{}
+	}
+	if n := len(page.Items); n > 0 {
+		newest := store.Cursor{CreatedAt: page.Items[0].CreatedAt, ID: page.Items[0].ID}
+		oldest := store.Cursor{CreatedAt: page.Items[n-1].CreatedAt, ID: page.Items[n-1].ID}
+		if filter.After != nil {
+			page.PrevCursor = oldest.String()
+			if hasMore {
+				page.NextCursor = newest.String()
+			}
+		} else {
+			page.PrevCursor = newest.String()
+			if hasMore {
+				page.NextCursor = oldest.String()
+			}
 		}
+	}
+	json.NewEncoder(w).Encode(page)
+}
 
-		// Экранируем HTML при выводе
-		wish.Name = html.EscapeString(wish.Name)
-		wish.Message = html.EscapeString(wish.Message)
+// parseListFilter разбирает query-параметры GET /api/wishes
+func parseListFilter(params url.Values) (store.ListFilter, error) {
+	filter := store.ListFilter{Limit: defaultPageLimit}
 
-		wishes = append(wishes, wish)
+	if v := params.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return filter, fmt.Errorf("limit должен быть положительным числом")
+		}
+		filter.Limit = min(limit, maxPageLimit)
+	}
+	if v := params.Get("before"); v != "" {
+		c, err := store.ParseCursor(v)
+		if err != nil {
+			return filter, fmt.Errorf("некорректный курсор before")
+		}
+		filter.Before = &c
+	}
+	if v := params.Get("after"); v != "" {
+		c, err := store.ParseCursor(v)
+		if err != nil {
+			return filter, fmt.Errorf("некорректный курсор after")
+		}
+		filter.After = &c
+	}
+	if filter.Before != nil && filter.After != nil {
+		return filter, fmt.Errorf("before и after нельзя указывать одновременно")
+	}
+	if v := params.Get("since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, fmt.Errorf("since должен быть в формате RFC3339")
+		}
+		filter.Since = since
+	}
+	if q := strings.TrimSpace(params.Get("q")); q != "" {
+		if len(q) > 100 {
+			return filter, fmt.Errorf("q не длиннее 100 символов")
+		}
+		// В БД текст хранится уже экранированным (см. cleanInput), ищем в том же виде
+		filter.Query = html.EscapeString(q)
 	}
+	return filter, nil
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(wishes)
+// wishRequest — тело POST /api/wish
+type wishRequest struct {
+	Name        string `json:"name"`
+	Message     string `json:"message"`
+	InviteToken string `json:"invite_token"`
+	// Website — скрытое поле-ловушка: люди его не видят, боты заполняют
+	Website string `json:"website"`
+	// Challenge — токен из GET /api/challenge, Solution — решение его задачи
+	Challenge string `json:"challenge"`
+	Solution  string `json:"solution"`
+}
+
+// spamRejections — ответ гостю на отклонённое проверкой пожелание
+var spamRejections = map[string]string{
+	spam.CheckDuplicate: "Такое пожелание уже отправлено",
 }
 
 // POST /api/wish — добавить пожелание
-func AddWish(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) AddWish(w http.ResponseWriter, r *http.Request) {
 	log.Printf("AddWish: received %s request from %s", r.Method, r.RemoteAddr)
 	log.Printf("Headers: %+v", r.Header)
 
@@ -68,12 +198,23 @@ func AddWish(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var wish models.Wish
-	if err := json.NewDecoder(r.Body).Decode(&wish); err != nil {
+	var req wishRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		errorResponse(w, "Invalid JSON", http.StatusBadRequest)
 		return
 	}
 
+	// По персональной ссылке пожелание подписывается именем гостя из списка
+	wish := models.Wish{Name: req.Name, Message: req.Message}
+	if req.InviteToken != "" {
+		guest, err := h.guestByInvite(r.Context(), req.InviteToken)
+		if !inviteOK(w, err) {
+			return
+		}
+		wish.Name = guest.Name
+		wish.GuestID = &guest.ID
+	}
+
 	// Очистка и валидация
 	wish.Name = cleanInput(wish.Name)
 	wish.Message = cleanInput(wish.Message)
@@ -90,39 +231,125 @@ func AddWish(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Сохраняем в БД
-	err := database.DB.QueryRow(
-		"INSERT INTO wishes (name, message) VALUES ($1, $2) RETURNING id, created_at",
-		wish.Name, wish.Message,
-	).Scan(&wish.ID, &wish.CreatedAt)
+	// Токен формы: выдан этим сервером, форму заполняли не быстрее человека, задача решена.
+	// Тратится он только после проверок содержимого, чтобы отклонённое пожелание можно было исправить.
+	if !h.challengeOK(w, req) {
+		return
+	}
+
+	// Проверки содержимого: подозрительное пожелание отклоняется или уходит на модерацию
+	check := h.cfg.Spam.Run(r.Context(), spam.Submission{
+		Name:     wish.Name,
+		Message:  wish.Message,
+		Honeypot: req.Website,
+		Invited:  wish.GuestID != nil,
+	})
+	if check.Action != spam.Allow {
+		log.Printf("⚠️ Пожелание от %q: %s (%s → %s)", wish.Name, check.Reason, check.Check, check.Action)
+	}
+	if check.Action == spam.Reject {
+		message, ok := spamRejections[check.Check]
+		if !ok {
+			message = "Пожелание не прошло автоматическую проверку"
+		}
+		errorResponse(w, message, http.StatusUnprocessableEntity)
+		return
+	}
+
+	// Статус задаёт сервер: в режиме модерации пожелание ждёт одобрения
+	wish.Status = models.StatusApproved
+	if h.cfg.Moderation || check.Action == spam.Moderate {
+		wish.Status = models.StatusPending
+	}
+
+	// Адресатов уведомления находим заранее, чтобы не держать транзакцию ради запроса к БД
+	recipients, err := h.outbox.Recipients(r.Context())
+	if err != nil {
+		log.Printf("Database error: %v", err)
+		errorResponse(w, "Ошибка базы данных", http.StatusInternalServerError)
+		return
+	}
+
+	// Токен тратится перед сохранением: из двух одновременных отправок пройдёт одна.
+	// Если сохранить не удалось, токен возвращается и гость повторит отправку с ним.
+	if !h.consumeChallenge(w, req) {
+		return
+	}
 
+	// Сохраняем в БД вместе с уведомлением в outbox — одной транзакцией
+	err = h.store.Create(r.Context(), &wish, func(saved models.Wish) ([]models.OutboxEntry, error) {
+		return h.outbox.Entries(wishMessage(saved, check.Reason), recipients)
+	})
 	if err != nil {
 		log.Printf("Database error: %v", err)
+		h.releaseChallenge(req)
 		errorResponse(w, "Ошибка базы данных", http.StatusInternalServerError)
 		return
 	}
+	h.outbox.Kick()
 
 	// Экранируем перед ответом
-	safeName := html.EscapeString(wish.Name)
-	safeMessage := html.EscapeString(wish.Message)
+	saved := publicWish(wish)
 
-	// Отправляем уведомление в Telegram (асинхронно)
-	go telegram.Send(fmt.Sprintf(
-		"💌 <b>Новое пожелание</b>\n\n"+
-			"<b>Гость:</b> %s\n"+
-			"<i>%s</i>",
-		safeName, safeMessage,
-	))
+	// Сообщаем подписчикам ленты; ожидающие модерации появятся после одобрения
+	if wish.Status == models.StatusApproved {
+		h.feed.Publish(events.WishCreated, saved)
+	}
 
 	// Ответ клиенту
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(models.Wish{
-		ID:        wish.ID,
-		Name:      safeName,
-		Message:   safeMessage,
-		CreatedAt: wish.CreatedAt,
-	})
+	json.NewEncoder(w).Encode(saved)
+}
+
+// wishMessage — уведомление о новом пожелании; кнопки модерации увидит только Telegram.
+// flag — почему проверка отправила пожелание на модерацию (пусто — не отправляла).
+func wishMessage(wish models.Wish, flag string) notify.Message {
+	text := wishNotice(wish)
+	if flag != "" {
+		// Причина цитирует текст пожелания, а он уже экранирован
+		text += "\n\n⚠️ <b>Автопроверка:</b> " + wishHTML(flag)
+	}
+	msg := notify.Message{Event: notify.EventWishCreated, Text: text, Data: publicWish(wish)}
+	if wish.Status == models.StatusPending {
+		msg.Buttons = moderationButtons(wish.ID)
+	}
+	return msg
+}
+
+// publicWish экранирует пожелание для отдачи наружу
+func publicWish(w models.Wish) models.Wish {
+	w.Name = html.EscapeString(w.Name)
+	w.Message = html.EscapeString(w.Message)
+	return w
+}
+
+// wishNotice — текст уведомления о новом пожелании для Telegram; текст в базе уже экранирован
+func wishNotice(w models.Wish) string {
+	title := "💌 <b>Новое пожелание</b>"
+	if w.Status == models.StatusPending {
+		title = "💌 <b>Новое пожелание</b> (№" + strconv.Itoa(w.ID) + ", ждёт модерации)"
+	}
+	return fmt.Sprintf(
+		"%s\n\n"+
+			"<b>Гость:</b> %s\n"+
+			"<i>%s</i>",
+		title, wishHTML(w.Name), wishHTML(w.Message),
+	)
+}
+
+// moderationButtons — кнопки «Одобрить / Отклонить» под уведомлением
+func moderationButtons(id int) [][]telegram.InlineButton {
+	return [][]telegram.InlineButton{{
+		{Text: "✅ Одобрить", Data: fmt.Sprintf("mod:%s:%d", models.StatusApproved, id)},
+		{Text: "🚫 Отклонить", Data: fmt.Sprintf("mod:%s:%d", models.StatusRejected, id)},
+	}}
+}
+
+// wishHTML — текст пожелания из БД для HTML-сообщения в Telegram. В БД он уже экранирован
+// (см. cleanInput), поэтому сначала раскодируем его, чтобы не экранировать дважды.
+func wishHTML(s string) string {
+	return html.EscapeString(html.UnescapeString(s))
 }
 
 // Очистка ввода: удаляем теги и экранируем
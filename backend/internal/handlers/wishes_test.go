@@ -0,0 +1,241 @@
+// backend/internal/handlers/wishes_test.go
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"wedding-backend/internal/admins"
+	"wedding-backend/internal/challenge"
+	"wedding-backend/internal/events"
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/outbox"
+	"wedding-backend/internal/spam"
+	"wedding-backend/internal/store"
+	"wedding-backend/internal/telegram"
+)
+
+func TestMain(m *testing.M) {
+	// Обработчики подробно логируют каждый запрос — в тестах это только шум
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// newTestHandler — обработчики поверх хранилища в памяти, без Telegram и каналов уведомлений
+func newTestHandler(t *testing.T, cfg Config) (*Handler, *store.Memory) {
+	t.Helper()
+	t.Setenv("CHAT_ID", "")
+	t.Setenv("ADMINS", "")
+
+	s := store.NewMemory()
+	registry, err := admins.FromEnv(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Location == nil {
+		cfg.Location = time.UTC
+	}
+	return New(s, events.NewBroadcaster(10), outbox.New(s, nil, 0), telegram.NewClient(""), registry, nil, cfg), s
+}
+
+func postWish(t *testing.T, h *Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.AddWish(w, httptest.NewRequest(http.MethodPost, "/api/wish", strings.NewReader(body)))
+	return w
+}
+
+func getWishes(t *testing.T, h *Handler, query url.Values) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.GetWishes(w, httptest.NewRequest(http.MethodGet, "/api/wishes?"+query.Encode(), nil))
+	return w
+}
+
+func decode[T any](t *testing.T, w *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.NewDecoder(w.Body).Decode(&v); err != nil {
+		t.Fatalf("ответ не JSON: %v", err)
+	}
+	return v
+}
+
+func TestAddWish(t *testing.T) {
+	h, s := newTestHandler(t, Config{})
+
+	w := postWish(t, h, `{"name":"Аня","message":"Счастья <b>вам</b>!"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("статус %d, ожидали 201: %s", w.Code, w.Body)
+	}
+	saved := decode[models.Wish](t, w)
+	if saved.ID == 0 || saved.Status != models.StatusApproved {
+		t.Errorf("сохранено %+v", saved)
+	}
+
+	stored, err := s.Get(context.Background(), saved.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Message != "Счастья вам!" {
+		t.Errorf("теги не вырезаны: %q", stored.Message)
+	}
+
+	list := decode[[]models.Wish](t, getWishes(t, h, nil))
+	if len(list) != 1 || list[0].ID != saved.ID {
+		t.Errorf("GET /api/wishes вернул %+v", list)
+	}
+}
+
+func TestAddWishValidation(t *testing.T) {
+	h, s := newTestHandler(t, Config{})
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"битый JSON", `{"name":`, http.StatusBadRequest},
+		{"без имени", `{"name":"  ","message":"Ура"}`, http.StatusBadRequest},
+		{"длинное имя", `{"name":"` + strings.Repeat("я", 101) + `","message":"Ура"}`, http.StatusBadRequest},
+		{"пустое пожелание", `{"name":"Аня","message":"<i></i>"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := postWish(t, h, tt.body); w.Code != tt.want {
+				t.Errorf("статус %d, ожидали %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	h.AddWish(w, httptest.NewRequest(http.MethodGet, "/api/wish", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /api/wish: статус %d", w.Code)
+	}
+
+	if list, _ := s.List(context.Background(), store.ListFilter{}); len(list) != 0 {
+		t.Errorf("сохранены некорректные пожелания: %+v", list)
+	}
+
+	// Слишком длинное пожелание обрезается cleanInput до 500 байт
+	saved := decode[models.Wish](t, postWish(t, h, `{"name":"Аня","message":"`+strings.Repeat("a", 600)+`"}`))
+	if len(saved.Message) != 500 {
+		t.Errorf("длина пожелания %d, ожидали 500", len(saved.Message))
+	}
+}
+
+func TestAddWishModeration(t *testing.T) {
+	h, s := newTestHandler(t, Config{Moderation: true})
+
+	saved := decode[models.Wish](t, postWish(t, h, `{"name":"Аня","message":"Ура"}`))
+	if saved.Status != models.StatusPending {
+		t.Fatalf("статус %q, ожидали pending", saved.Status)
+	}
+	if list := decode[[]models.Wish](t, getWishes(t, h, nil)); len(list) != 0 {
+		t.Errorf("неодобренное пожелание видно публично: %+v", list)
+	}
+
+	if err := s.SetStatus(context.Background(), saved.ID, models.StatusApproved); err != nil {
+		t.Fatal(err)
+	}
+	if list := decode[[]models.Wish](t, getWishes(t, h, nil)); len(list) != 1 {
+		t.Errorf("одобренное пожелание не видно: %+v", list)
+	}
+}
+
+func TestGetWishesPagination(t *testing.T) {
+	h, _ := newTestHandler(t, Config{})
+	for _, name := range []string{"1", "2", "3", "4", "5"} {
+		if w := postWish(t, h, `{"name":"`+name+`","message":"Ура"}`); w.Code != http.StatusCreated {
+			t.Fatalf("статус %d: %s", w.Code, w.Body)
+		}
+	}
+
+	names := func(p wishesPage) string {
+		var s []string
+		for _, w := range p.Items {
+			s = append(s, w.Name)
+		}
+		return strings.Join(s, ",")
+	}
+
+	first := decode[wishesPage](t, getWishes(t, h, url.Values{"limit": {"2"}}))
+	if names(first) != "5,4" || first.NextCursor == "" {
+		t.Fatalf("первая страница: %s, next %q", names(first), first.NextCursor)
+	}
+	second := decode[wishesPage](t, getWishes(t, h, url.Values{"limit": {"2"}, "before": {first.NextCursor}}))
+	if names(second) != "3,2" || second.NextCursor == "" {
+		t.Fatalf("вторая страница: %s", names(second))
+	}
+	last := decode[wishesPage](t, getWishes(t, h, url.Values{"limit": {"2"}, "before": {second.NextCursor}}))
+	if names(last) != "1" || last.NextCursor != "" {
+		t.Fatalf("последняя страница: %s, next %q", names(last), last.NextCursor)
+	}
+
+	// Назад от последней страницы: ближайшие к курсору записи
+	back := decode[wishesPage](t, getWishes(t, h, url.Values{"limit": {"2"}, "after": {last.PrevCursor}}))
+	if names(back) != "3,2" || back.NextCursor == "" {
+		t.Fatalf("страница назад: %s, next %q", names(back), back.NextCursor)
+	}
+
+	for _, q := range []url.Values{
+		{"limit": {"0"}},
+		{"before": {"не-курсор"}},
+		{"before": {first.NextCursor}, "after": {first.PrevCursor}},
+		{"since": {"вчера"}},
+	} {
+		if w := getWishes(t, h, q); w.Code != http.StatusBadRequest {
+			t.Errorf("%v: статус %d, ожидали 400", q, w.Code)
+		}
+	}
+}
+
+func TestAddWishChallenge(t *testing.T) {
+	issuer := challenge.New(nil, 0, time.Hour, 0)
+	h, _ := newTestHandler(t, Config{
+		Challenge: issuer,
+		Spam:      spam.Pipeline{{Check: spam.Links{}, Action: spam.Reject}},
+	})
+	token := issuer.Issue(time.Now()).Token
+
+	if w := postWish(t, h, `{"name":"Аня","message":"Счастья!"}`); w.Code != http.StatusForbidden {
+		t.Errorf("без токена: %d", w.Code)
+	}
+
+	// Отклонённое проверками пожелание не тратит токен: гость исправит текст и отправит снова
+	body := `{"name":"Аня","message":"%s","challenge":"` + token + `"}`
+	if w := postWish(t, h, fmt.Sprintf(body, "Смотрите example.com")); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("со ссылкой: %d", w.Code)
+	}
+	if w := postWish(t, h, fmt.Sprintf(body, "Счастья!")); w.Code != http.StatusCreated {
+		t.Fatalf("после исправления: %d %s", w.Code, w.Body)
+	}
+	if w := postWish(t, h, fmt.Sprintf(body, "Ещё раз счастья!")); w.Code != http.StatusForbidden {
+		t.Errorf("повтор токена: %d", w.Code)
+	}
+}
+
+func TestWishMessageEscaping(t *testing.T) {
+	wish := models.Wish{ID: 7, Name: cleanInput("Д'Артаньян & Ко"), Message: cleanInput(`Кричим "горько"`), Status: models.StatusPending}
+	msg := wishMessage(wish, "ссылка «"+cleanInput("a&b.ru")+"»")
+
+	for _, want := range []string{"Д&#39;Артаньян &amp; Ко", "Кричим &#34;горько&#34;", "ссылка «a&amp;b.ru»"} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("нет %q в %q", want, msg.Text)
+		}
+	}
+	if strings.Contains(msg.Text, "&amp;#") || strings.Contains(msg.Text, "&amp;amp;") {
+		t.Errorf("текст экранирован дважды: %q", msg.Text)
+	}
+}
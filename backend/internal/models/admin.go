@@ -0,0 +1,33 @@
+// backend/internal/models/admin.go
+package models
+
+import "time"
+
+// Роли администраторов бота, от младшей к старшей
+const (
+	RoleViewer    = "viewer"    // просмотр пожеланий и сводок
+	RoleModerator = "moderator" // модерация и удаление отдельных пожеланий
+	RoleOwner     = "owner"     // всё, включая /delete_all, /restore и управление админами
+)
+
+// Admin — пользователь Telegram с доступом к боту
+type Admin struct {
+	ChatID    int64     `json:"chat_id"`
+	Role      string    `json:"role"`
+	Name      string    `json:"name,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// roleRank — порядок ролей для сравнения прав
+var roleRank = map[string]int{RoleViewer: 1, RoleModerator: 2, RoleOwner: 3}
+
+// ValidRole проверяет, что роль — одна из известных
+func ValidRole(role string) bool {
+	_, ok := roleRank[role]
+	return ok
+}
+
+// RoleAllows сообщает, хватает ли роли role для действия с ролью required
+func RoleAllows(role, required string) bool {
+	return ValidRole(role) && roleRank[role] >= roleRank[required]
+}
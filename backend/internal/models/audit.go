@@ -0,0 +1,36 @@
+// backend/internal/models/audit.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Действия в журнале аудита
+const (
+	AuditApprove     = "approve"
+	AuditReject      = "reject"
+	AuditPending     = "pending"
+	AuditDelete      = "delete"
+	AuditDeleteAll   = "delete_all"
+	AuditUndelete    = "undelete"
+	AuditUndeleteAll = "undelete_all"
+	AuditRestore     = "restore"
+	AuditPurge       = "purge"
+	AuditAdminAdd    = "admin_add"
+	AuditAdminRemove = "admin_remove"
+	AuditGuests      = "guests_import"
+)
+
+// AuditEvent — запись журнала: кто, что и с какими пожеланиями сделал.
+// Before и After — JSON-снимки до и после изменения (null, если снимка нет).
+type AuditEvent struct {
+	ID int64 `json:"id"`
+	// ActorID — chat ID администратора; 0 — сам сервер (например, очистка корзины)
+	ActorID   int64           `json:"actor_id"`
+	Action    string          `json:"action"`
+	WishIDs   []int           `json:"wish_ids"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
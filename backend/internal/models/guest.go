@@ -0,0 +1,14 @@
+// backend/internal/models/guest.go
+package models
+
+import "time"
+
+// Guest — приглашённый гость с персональной ссылкой
+type Guest struct {
+	ID        int       `json:"id"`
+	Token     string    `json:"token"`
+	Name      string    `json:"name"`
+	PartySize int       `json:"party_size"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,26 @@
+// backend/internal/models/outbox.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Статусы доставки уведомления из outbox
+const (
+	OutboxPending = "pending"
+	OutboxSent    = "sent"
+	OutboxDead    = "dead"
+)
+
+// OutboxEntry — уведомление, ожидающее доставки в один канал
+type OutboxEntry struct {
+	ID            int             `json:"id"`
+	Channel       string          `json:"channel"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     string          `json:"last_error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
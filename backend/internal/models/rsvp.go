@@ -0,0 +1,30 @@
+// backend/internal/models/rsvp.go
+package models
+
+import "time"
+
+// Варианты ответа на приглашение
+const (
+	AttendingYes   = "yes"
+	AttendingNo    = "no"
+	AttendingMaybe = "maybe"
+)
+
+// RSVP — ответ гостя на приглашение
+type RSVP struct {
+	ID            int       `json:"id"`
+	Token         string    `json:"token,omitempty"`
+	Name          string    `json:"name"`
+	Attending     string    `json:"attending"`
+	PlusOnes      int       `json:"plus_ones"`
+	Dietary       string    `json:"dietary"`
+	NeedsTransfer bool      `json:"needs_transfer"`
+	GuestID       *int      `json:"guest_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ValidAttending проверяет вариант ответа
+func ValidAttending(s string) bool {
+	return s == AttendingYes || s == AttendingNo || s == AttendingMaybe
+}
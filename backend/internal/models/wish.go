@@ -1,10 +1,28 @@
 // backend/internal/models/wish.go
 package models
 
+import "time"
+
+// Статусы модерации пожелания
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
 // Wish — модель пожелания
 type Wish struct {
-	ID        int    `json:"id"`
-	Name      string `json:"name"`
-	Message   string `json:"message"`
-	CreatedAt string `json:"created_at"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Message   string    `json:"message"`
+	Status    string    `json:"status,omitempty"`
+	GuestID   *int      `json:"guest_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// DeletedAt — когда пожелание перенесено в корзину; nil — не удалено
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ValidStatus проверяет, что статус — один из известных
+func ValidStatus(s string) bool {
+	return s == StatusPending || s == StatusApproved || s == StatusRejected
 }
@@ -0,0 +1,110 @@
+// backend/internal/notify/config.go
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"wedding-backend/internal/telegram"
+)
+
+// FromEnv собирает каналы уведомлений из переменных окружения.
+// NOTIFIERS — список через запятую: telegram, email, webhook (по умолчанию telegram;
+// без TG_TOKEN или адресатов он тогда молча отключается, как раньше при локальном запуске).
+// recipients — адресаты в Telegram по умолчанию (администраторы бота).
+//
+//	telegram: TG_TOKEN; адресаты — администраторы (CHAT_ID, ADMINS, БД)
+//	          или NOTIFY_CHAT_ID — общий чат вместо личных сообщений (TELEGRAM_API_URL — другой адрес Bot API)
+//	email:    SMTP_HOST, SMTP_PORT (587), SMTP_USER, SMTP_PASSWORD, SMTP_FROM, NOTIFY_EMAIL_TO (через запятую)
+//	webhook:  NOTIFY_WEBHOOK_URL, NOTIFY_WEBHOOK_SECRET
+func FromEnv(recipients func(ctx context.Context) ([]int64, error)) (Multi, error) {
+	names := os.Getenv("NOTIFIERS")
+	explicit := names != ""
+	if !explicit {
+		names = "telegram"
+	}
+
+	var notifiers Multi
+	for _, name := range strings.Split(names, ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+			continue
+
+		case "telegram":
+			t := &Telegram{Bot: telegram.FromEnv(), Recipients: recipients}
+			if group := os.Getenv("NOTIFY_CHAT_ID"); group != "" {
+				id, err := strconv.ParseInt(group, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("telegram: NOTIFY_CHAT_ID должен быть числом")
+				}
+				t.Recipients = func(context.Context) ([]int64, error) { return []int64{id}, nil }
+			}
+			ok, err := t.ready()
+			if err != nil {
+				return nil, fmt.Errorf("telegram: %w", err)
+			}
+			if !ok && !explicit {
+				continue
+			}
+			if !ok {
+				return nil, fmt.Errorf("telegram: нужны TG_TOKEN и адресат — CHAT_ID, ADMINS, NOTIFY_CHAT_ID или администратор в БД")
+			}
+			notifiers = append(notifiers, t)
+
+		case "email":
+			e := &Email{
+				Host:     os.Getenv("SMTP_HOST"),
+				Port:     os.Getenv("SMTP_PORT"),
+				Username: os.Getenv("SMTP_USER"),
+				Password: os.Getenv("SMTP_PASSWORD"),
+				From:     os.Getenv("SMTP_FROM"),
+				To:       splitList(os.Getenv("NOTIFY_EMAIL_TO")),
+			}
+			if e.Port == "" {
+				e.Port = "587"
+			}
+			if e.Host == "" || e.From == "" || len(e.To) == 0 {
+				return nil, fmt.Errorf("email: нужны SMTP_HOST, SMTP_FROM и NOTIFY_EMAIL_TO")
+			}
+			notifiers = append(notifiers, e)
+
+		case "webhook":
+			w := &Webhook{URL: os.Getenv("NOTIFY_WEBHOOK_URL"), Secret: os.Getenv("NOTIFY_WEBHOOK_SECRET")}
+			if w.URL == "" {
+				return nil, fmt.Errorf("webhook: нужен NOTIFY_WEBHOOK_URL")
+			}
+			notifiers = append(notifiers, w)
+
+		default:
+			return nil, fmt.Errorf("неизвестный канал уведомлений %q", name)
+		}
+	}
+	return notifiers, nil
+}
+
+// ready сообщает, есть ли у канала бот и хотя бы один адресат: CHAT_ID не обязателен,
+// если администраторы заданы в ADMINS или в БД
+func (t *Telegram) ready() (bool, error) {
+	if !t.Bot.Configured() {
+		return false, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ids, err := t.Targets(ctx)
+	return len(ids) > 0, err
+}
+
+// splitList разбирает список через запятую, пропуская пустые элементы
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
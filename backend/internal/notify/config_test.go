@@ -0,0 +1,45 @@
+// backend/internal/notify/config_test.go
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFromEnvTelegramRecipients(t *testing.T) {
+	admins := func(ids ...int64) func(context.Context) ([]int64, error) {
+		return func(context.Context) ([]int64, error) { return ids, nil }
+	}
+	for _, key := range []string{"CHAT_ID", "NOTIFY_CHAT_ID", "TELEGRAM_API_URL"} {
+		t.Setenv(key, "")
+	}
+	t.Setenv("TG_TOKEN", "test")
+	t.Setenv("NOTIFIERS", "telegram")
+
+	// Администраторы из ADMINS или БД — CHAT_ID не нужен
+	n, err := FromEnv(admins(42))
+	if err != nil || len(n) != 1 {
+		t.Fatalf("с администраторами без CHAT_ID: %v, %v", n, err)
+	}
+
+	if _, err := FromEnv(admins()); err == nil {
+		t.Error("канал без адресатов принят")
+	}
+
+	t.Setenv("NOTIFY_CHAT_ID", "-100")
+	if n, err := FromEnv(admins()); err != nil || len(n) != 1 {
+		t.Errorf("с NOTIFY_CHAT_ID: %v, %v", n, err)
+	}
+	t.Setenv("NOTIFY_CHAT_ID", "")
+
+	if _, err := FromEnv(func(context.Context) ([]int64, error) { return nil, errors.New("нет БД") }); err == nil {
+		t.Error("ошибка списка администраторов проглочена")
+	}
+
+	// Без явного NOTIFIERS канал без адресатов молча отключается
+	t.Setenv("NOTIFIERS", "")
+	if n, err := FromEnv(admins()); err != nil || len(n) != 0 {
+		t.Errorf("по умолчанию без адресатов: %v, %v", n, err)
+	}
+}
@@ -0,0 +1,112 @@
+// backend/internal/notify/email.go
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Email — уведомления по почте через SMTP (например, для родителей без Telegram)
+type Email struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+func (e *Email) Name() string { return "email" }
+
+func (e *Email) Notify(ctx context.Context, msg Message) error {
+	// Тема — первая строка уведомления без разметки
+	plain := PlainText(msg.Text)
+	subject, _, _ := strings.Cut(plain, "\n")
+
+	// Теги Telegram (<b>, <i>, <code>) — подмножество HTML, достаточно перевести строки
+	body := "<html><body style=\"font-family: sans-serif\">" +
+		strings.ReplaceAll(msg.Text, "\n", "<br>\n") +
+		"</body></html>"
+
+	var b strings.Builder
+	b.WriteString("From: " + e.From + "\r\n")
+	b.WriteString("To: " + strings.Join(e.To, ", ") + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", strings.TrimSpace(subject)) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/html; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(body)
+
+	var auth smtp.Auth
+	if e.Username != "" {
+		auth = smtp.PlainAuth("", e.Username, e.Password, e.Host)
+	}
+
+	if err := e.send(ctx, auth, []byte(b.String())); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	return nil
+}
+
+// send — smtp.SendMail с учётом ctx: зависший сервер не держит воркер дольше таймаута отправки
+func (e *Email) send(ctx context.Context, auth smtp.Auth, data []byte) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(e.Host, e.Port))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	// Отмена ctx без дедлайна тоже прерывает разговор с сервером
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, e.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: e.Host}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("сервер не поддерживает AUTH")
+		}
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(e.From); err != nil {
+		return err
+	}
+	for _, to := range e.To {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
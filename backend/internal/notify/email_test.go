@@ -0,0 +1,40 @@
+// backend/internal/notify/email_test.go
+package notify
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestEmailTimeout(t *testing.T) {
+	// Сервер принимает соединение и молчит
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	e := &Email{Host: host, Port: port, From: "bot@example.com", To: []string{"mama@example.com"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := e.Notify(ctx, Message{Text: "Новое пожелание"}); err == nil {
+		t.Fatal("молчащий сервер принял письмо")
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Errorf("отправка ждала %s вместо таймаута", d)
+	}
+}
@@ -0,0 +1,77 @@
+// backend/internal/notify/notify.go
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"wedding-backend/internal/telegram"
+)
+
+// Типы уведомлений (поле event во внешнем вебхуке)
+const (
+	EventWishCreated = "wish.created"
+	EventRSVPCreated = "rsvp.created"
+	EventRSVPUpdated = "rsvp.updated"
+)
+
+// Message — уведомление для пары и родителей.
+// Text — в HTML-разметке Telegram (<b>, <i>, <code>), Buttons понимает только Telegram,
+// Data — исходные данные для машинных получателей (вебхук).
+// ChatID — конкретный адресат в Telegram (0 — все адресаты канала).
+// Сообщение хранится в outbox в виде JSON, поэтому у полей есть теги.
+type Message struct {
+	Event   string                    `json:"event"`
+	Text    string                    `json:"text"`
+	Buttons [][]telegram.InlineButton `json:"buttons,omitempty"`
+	Data    any                       `json:"data,omitempty"`
+	ChatID  int64                     `json:"chat_id,omitempty"`
+}
+
+// Notifier доставляет уведомление по одному каналу
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Fanout — канал с несколькими адресатами. Outbox ставит отдельную строку на каждого
+// (адресат — Message.ChatID), чтобы повтор после частичной неудачи не дублировал уже доставленное.
+type Fanout interface {
+	// Targets возвращает адресатов; outbox спрашивает их до транзакции со вставкой
+	Targets(ctx context.Context) ([]int64, error)
+}
+
+// Multi рассылает уведомление во все каналы; ошибка одного не мешает остальным
+type Multi []Notifier
+
+func (m Multi) Name() string {
+	names := make([]string, len(m))
+	for i, n := range m {
+		names[i] = n.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+func (m Multi) Notify(ctx context.Context, msg Message) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// tagRegex — теги Telegram-разметки, которые убираем для простого текста
+var tagRegex = regexp.MustCompile(`<[^>]+>`)
+
+// PlainText убирает из Telegram-разметки теги и HTML-сущности
+func PlainText(text string) string {
+	text = tagRegex.ReplaceAllString(text, "")
+	return htmlUnescaper.Replace(text)
+}
+
+var htmlUnescaper = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&quot;", `"`, "&#34;", `"`, "&#39;", "'", "&amp;", "&")
@@ -0,0 +1,54 @@
+// backend/internal/notify/telegram.go
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"wedding-backend/internal/telegram"
+)
+
+// Telegram — уведомления администраторам бота или в общий чат
+type Telegram struct {
+	Bot *telegram.Client
+	// Recipients возвращает chat ID адресатов
+	Recipients func(ctx context.Context) ([]int64, error)
+}
+
+func (t *Telegram) Name() string { return "telegram" }
+
+func (t *Telegram) Targets(ctx context.Context) ([]int64, error) {
+	return t.Recipients(ctx)
+}
+
+// Split делает копию уведомления для каждого адресата
+func (t *Telegram) Split(ctx context.Context, msg Message) ([]Message, error) {
+	if msg.ChatID != 0 {
+		return []Message{msg}, nil
+	}
+	ids, err := t.Targets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	msgs := make([]Message, len(ids))
+	for i, id := range ids {
+		msgs[i] = msg
+		msgs[i].ChatID = id
+	}
+	return msgs, nil
+}
+
+func (t *Telegram) Notify(ctx context.Context, msg Message) error {
+	msgs, err := t.Split(ctx, msg)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, m := range msgs {
+		if _, err := t.Bot.SendMessage(ctx, m.ChatID, m.Text, m.Buttons); err != nil {
+			errs = append(errs, fmt.Errorf("чат %d: %w", m.ChatID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,85 @@
+// backend/internal/notify/webhook.go
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Webhook — уведомления JSON-запросом на внешний адрес.
+// Тело подписывается HMAC-SHA256 с общим секретом:
+// X-Wedding-Signature: sha256=<hex(hmac(secret, timestamp + "." + body))>,
+// X-Wedding-Timestamp: <unix-время>, чтобы получатель мог отбросить повторы.
+type Webhook struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+// webhookPayload — тело запроса вебхука
+type webhookPayload struct {
+	Event  string    `json:"event"`
+	Text   string    `json:"text"`
+	Data   any       `json:"data,omitempty"`
+	SentAt time.Time `json:"sent_at"`
+}
+
+func (w *Webhook) Name() string { return "webhook" }
+
+func (w *Webhook) Notify(ctx context.Context, msg Message) error {
+	now := time.Now()
+	body, err := json.Marshal(webhookPayload{
+		Event:  msg.Event,
+		Text:   PlainText(msg.Text),
+		Data:   msg.Data,
+		SentAt: now.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Wedding-Event", msg.Event)
+	req.Header.Set("X-Wedding-Timestamp", timestamp)
+	if w.Secret != "" {
+		req.Header.Set("X-Wedding-Signature", "sha256="+Sign(w.Secret, timestamp, body))
+	}
+
+	client := w.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, snippet)
+	}
+	return nil
+}
+
+// Sign вычисляет подпись вебхука; получатель сравнивает её через hmac.Equal
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
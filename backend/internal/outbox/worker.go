@@ -0,0 +1,219 @@
+// backend/internal/outbox/worker.go
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/notify"
+	"wedding-backend/internal/store"
+	"wedding-backend/internal/telegram"
+)
+
+const (
+	// DefaultMaxAttempts — после стольких неудач уведомление уходит в dead-letter
+	DefaultMaxAttempts = 8
+	// pollInterval — как часто проверять очередь, если новых уведомлений не поступало
+	pollInterval = 5 * time.Second
+	// batchSize — сколько уведомлений забирать за один проход
+	batchSize = 20
+	// sendTimeout — сколько ждать один канал
+	sendTimeout = 30 * time.Second
+	// claimLease — на сколько забранная пачка скрыта от других экземпляров: уведомления
+	// отправляются по одному, и аренда должна пережить пачку, где каждый канал ждали до таймаута
+	claimLease = batchSize*sendTimeout + 5*time.Minute
+
+	backoffBase = 10 * time.Second
+	backoffMax  = time.Hour
+)
+
+// Worker доставляет уведомления из таблицы outbox по каналам.
+// Уведомление пишется в outbox до отправки (для пожеланий — в одной транзакции
+// с вставкой), поэтому медленный Telegram, 429 или перезапуск не теряют его.
+type Worker struct {
+	store       store.OutboxStore
+	channels    map[string]notify.Notifier
+	order       []string
+	maxAttempts int
+	kick        chan struct{}
+}
+
+// New создаёт воркер для каналов notifiers; maxAttempts <= 0 — DefaultMaxAttempts
+func New(s store.OutboxStore, notifiers []notify.Notifier, maxAttempts int) *Worker {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	w := &Worker{
+		store:       s,
+		channels:    make(map[string]notify.Notifier),
+		maxAttempts: maxAttempts,
+		kick:        make(chan struct{}, 1),
+	}
+	for _, n := range notifiers {
+		if _, ok := w.channels[n.Name()]; !ok {
+			w.order = append(w.order, n.Name())
+		}
+		w.channels[n.Name()] = n
+	}
+	return w
+}
+
+// Recipients — адресаты каналов с несколькими получателями, по имени канала
+type Recipients map[string][]int64
+
+// Recipients находит адресатов каналов. Для пожеланий его вызывают до транзакции со вставкой:
+// список администраторов — отдельный запрос к БД, и держать ради него транзакцию незачем.
+func (w *Worker) Recipients(ctx context.Context) (Recipients, error) {
+	r := make(Recipients)
+	for _, name := range w.order {
+		if f, ok := w.channels[name].(notify.Fanout); ok {
+			ids, err := f.Targets(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			r[name] = ids
+		}
+	}
+	return r, nil
+}
+
+// Entries раскладывает уведомление по строкам outbox — по одной на канал
+// и на каждого адресата из r; к БД не обращается
+func (w *Worker) Entries(msg notify.Message, r Recipients) ([]models.OutboxEntry, error) {
+	var entries []models.OutboxEntry
+	for _, name := range w.order {
+		msgs := []notify.Message{msg}
+		if ids, ok := r[name]; ok && msg.ChatID == 0 {
+			msgs = make([]notify.Message, len(ids))
+			for i, id := range ids {
+				msgs[i] = msg
+				msgs[i].ChatID = id
+			}
+		}
+		for _, m := range msgs {
+			payload, err := json.Marshal(m)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, models.OutboxEntry{Channel: name, Payload: payload})
+		}
+	}
+	return entries, nil
+}
+
+// Enqueue ставит уведомление в очередь и будит воркер
+func (w *Worker) Enqueue(ctx context.Context, msg notify.Message) error {
+	recipients, err := w.Recipients(ctx)
+	if err != nil {
+		return err
+	}
+	entries, err := w.Entries(msg, recipients)
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+	if err := w.store.EnqueueOutbox(ctx, entries); err != nil {
+		return err
+	}
+	w.Kick()
+	return nil
+}
+
+// Kick будит воркер, не дожидаясь очередной проверки очереди
+func (w *Worker) Kick() {
+	select {
+	case w.kick <- struct{}{}:
+	default:
+	}
+}
+
+// Run доставляет уведомления, пока не отменён ctx
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.kick:
+		}
+	}
+}
+
+// drain забирает готовые к отправке уведомления, пока очередь не опустеет
+func (w *Worker) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		entries, err := w.store.ClaimOutbox(ctx, batchSize, claimLease)
+		if err != nil {
+			log.Printf("❌ Ошибка чтения outbox: %v", err)
+			return
+		}
+		for _, e := range entries {
+			w.deliver(ctx, e)
+		}
+		if len(entries) < batchSize {
+			return
+		}
+	}
+}
+
+// deliver отправляет одно уведомление и записывает результат
+func (w *Worker) deliver(ctx context.Context, e models.OutboxEntry) {
+	err := w.send(ctx, e)
+	if err == nil {
+		if err := w.store.MarkOutboxSent(ctx, e.ID); err != nil {
+			log.Printf("❌ Ошибка записи в outbox: %v", err)
+		}
+		return
+	}
+
+	attempts := e.Attempts + 1
+	dead := attempts >= w.maxAttempts
+	next := time.Now().Add(retryDelay(attempts, err))
+	if dead {
+		log.Printf("💀 Уведомление #%d (%s) не доставлено после %d попыток: %v", e.ID, e.Channel, attempts, err)
+	} else {
+		log.Printf("⚠️ Уведомление #%d (%s), попытка %d: %v", e.ID, e.Channel, attempts, err)
+	}
+	if err := w.store.MarkOutboxFailed(ctx, e.ID, err.Error(), next, dead); err != nil {
+		log.Printf("❌ Ошибка записи в outbox: %v", err)
+	}
+}
+
+func (w *Worker) send(ctx context.Context, e models.OutboxEntry) error {
+	n, ok := w.channels[e.Channel]
+	if !ok {
+		return fmt.Errorf("канал %q не настроен", e.Channel)
+	}
+	var msg notify.Message
+	if err := json.Unmarshal(e.Payload, &msg); err != nil {
+		return fmt.Errorf("повреждённое уведомление: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	return n.Notify(ctx, msg)
+}
+
+// retryDelay — экспоненциальная пауза 10 с, 20 с, 40 с… до часа;
+// если Telegram сам назвал retry_after, ждём не меньше
+func retryDelay(attempts int, err error) time.Duration {
+	delay := backoffMax
+	if attempts < 16 {
+		delay = min(backoffBase<<(attempts-1), backoffMax)
+	}
+
+	var apiErr *telegram.APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		delay = max(delay, time.Duration(apiErr.RetryAfter)*time.Second)
+	}
+	return delay
+}
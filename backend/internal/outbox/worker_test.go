@@ -0,0 +1,63 @@
+// backend/internal/outbox/worker_test.go
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"wedding-backend/internal/notify"
+	"wedding-backend/internal/store"
+)
+
+// fanout — канал с двумя адресатами, считает обращения за ними
+type fanout struct{ calls int }
+
+func (f *fanout) Name() string                                       { return "telegram" }
+func (f *fanout) Notify(ctx context.Context, _ notify.Message) error { return nil }
+func (f *fanout) Targets(ctx context.Context) ([]int64, error) {
+	f.calls++
+	return []int64{1, 2}, nil
+}
+
+// single — канал с одним адресатом
+type single struct{}
+
+func (single) Name() string                                       { return "webhook" }
+func (single) Notify(ctx context.Context, _ notify.Message) error { return nil }
+
+func TestEntries(t *testing.T) {
+	f := &fanout{}
+	w := New(store.NewMemory(), []notify.Notifier{f, single{}}, 0)
+
+	recipients, err := w.Recipients(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := w.Entries(notify.Message{Text: "Привет"}, recipients)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.calls != 1 {
+		t.Errorf("адресатов запросили %d раз", f.calls)
+	}
+
+	var got []string
+	for _, e := range entries {
+		var msg notify.Message
+		if err := json.Unmarshal(e.Payload, &msg); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, fmt.Sprintf("%s:%d", e.Channel, msg.ChatID))
+	}
+	if len(got) != 3 || got[0] != "telegram:1" || got[1] != "telegram:2" || got[2] != "webhook:0" {
+		t.Errorf("строки outbox: %v", got)
+	}
+
+	// Уведомление конкретному чату не размножается
+	entries, _ = w.Entries(notify.Message{Text: "Привет", ChatID: 7}, recipients)
+	if len(entries) != 2 {
+		t.Errorf("строк для одного чата: %d", len(entries))
+	}
+}
@@ -0,0 +1,235 @@
+// backend/internal/ratelimit/ratelimit.go
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"wedding-backend/internal/store"
+)
+
+const (
+	// maxPeriod — самый длинный период лимита: вёдра старше него уже полные и удаляются
+	maxPeriod = 24 * time.Hour
+	// pruneEvery — как часто удалять неиспользуемые вёдра
+	pruneEvery = time.Hour
+	// maxPeek — сколько тела запроса читать в поисках invite_token
+	maxPeek = 64 << 10
+	// storeTimeout — запрос к хранилищу вёдер не должен задерживать гостя
+	storeTimeout = 2 * time.Second
+)
+
+// Limit — ведро на Burst запросов, которое полностью пополняется за Period.
+// Нулевой Limit ничего не ограничивает.
+type Limit struct {
+	Burst  int
+	Period time.Duration
+}
+
+// Off — лимит выключен
+func (l Limit) Off() bool {
+	return l.Burst <= 0
+}
+
+// rate — токенов в секунду
+func (l Limit) rate() float64 {
+	return float64(l.Burst) / l.Period.Seconds()
+}
+
+func (l Limit) String() string {
+	if l.Off() {
+		return "off"
+	}
+	return fmt.Sprintf("%d/%s", l.Burst, l.Period)
+}
+
+// ParseLimit разбирает «5/10m» — 5 запросов за 10 минут; «off» выключает лимит
+func ParseLimit(s string) (Limit, error) {
+	if s == "off" {
+		return Limit{}, nil
+	}
+	count, period, ok := strings.Cut(s, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("ожидается формат N/период, например 5/10m: %q", s)
+	}
+	burst, err := strconv.Atoi(count)
+	if err != nil || burst <= 0 {
+		return Limit{}, fmt.Errorf("число запросов должно быть положительным: %q", s)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d < time.Second || d > maxPeriod {
+		return Limit{}, fmt.Errorf("период должен быть от 1s до %s: %q", maxPeriod, s)
+	}
+	return Limit{Burst: burst, Period: d}, nil
+}
+
+// bucket — ведро лимита под ключом key
+func (l Limit) bucket(key string) store.Bucket {
+	return store.Bucket{Key: key, Burst: l.Burst, Rate: l.rate()}
+}
+
+// Limiter ограничивает частоту запросов с одного IP и по одной персональной ссылке
+type Limiter struct {
+	store store.RateLimitStore
+	// guests — по ним проверяется токен приглашения: ведро заводится только для настоящих ссылок
+	guests store.GuestStore
+	// IP — лимит на адрес клиента
+	IP Limit
+	// Invite — лимит на токен приглашения из тела запроса
+	Invite Limit
+	// ProxyHops — сколько доверенных прокси (например, балансировщик Render) дописывают
+	// адрес в X-Forwarded-For; 0 — заголовок игнорируется, клиент берётся из RemoteAddr
+	ProxyHops int
+
+	lastPrune atomic.Int64
+}
+
+// New создаёт ограничитель поверх хранилища вёдер; guests проверяет токены приглашений
+func New(s store.RateLimitStore, guests store.GuestStore, ip, invite Limit, proxyHops int) *Limiter {
+	return &Limiter{store: s, guests: guests, IP: ip, Invite: invite, ProxyHops: proxyHops}
+}
+
+// Wrap пропускает в next только POST-запросы, для которых есть токены во всех вёдрах;
+// остальным отвечает 429 с Retry-After
+func (l *Limiter) Wrap(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next(w, r)
+			return
+		}
+		l.prune()
+
+		ip := ClientIP(r, l.ProxyHops)
+		var buckets []store.Bucket
+		if !l.IP.Off() {
+			buckets = append(buckets, l.IP.bucket("ip:"+ip))
+		}
+		if !l.Invite.Off() {
+			if key := l.inviteKey(r); key != "" {
+				buckets = append(buckets, l.Invite.bucket("invite:"+key))
+			}
+		}
+		if wait := l.take(r.Context(), ip, buckets); wait > 0 {
+			tooManyRequests(w, wait)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// take возвращает паузу до следующего токена или 0, если запрос можно пропустить.
+// При ошибке хранилища запрос пропускается: гость важнее лимита.
+func (l *Limiter) take(ctx context.Context, ip string, buckets []store.Bucket) time.Duration {
+	if len(buckets) == 0 {
+		return 0
+	}
+	ctx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+
+	ok, wait, err := l.store.TakeTokens(ctx, buckets)
+	if err != nil {
+		log.Printf("⚠️ Ограничитель частоты недоступен, запрос пропущен: %v", err)
+		return 0
+	}
+	if ok {
+		return 0
+	}
+	log.Printf("⚠️ Слишком частые пожелания с %s, повтор через %s", ip, wait.Round(time.Second))
+	return max(wait, time.Second)
+}
+
+// prune раз в pruneEvery удаляет неиспользуемые вёдра в фоне
+func (l *Limiter) prune() {
+	now := time.Now()
+	last := l.lastPrune.Load()
+	if now.Sub(time.Unix(0, last)) < pruneEvery || !l.lastPrune.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := l.store.PruneTokens(ctx, now.Add(-maxPeriod)); err != nil {
+			log.Printf("❌ Ошибка очистки ограничителя частоты: %v", err)
+		}
+	}()
+}
+
+// ClientIP — адрес клиента. За proxyHops доверенными прокси он стоит в X-Forwarded-For
+// на proxyHops-й позиции с конца: всё левее мог дописать сам клиент.
+func ClientIP(r *http.Request, proxyHops int) string {
+	if proxyHops > 0 {
+		var hops []string
+		for _, v := range r.Header.Values("X-Forwarded-For") {
+			for _, hop := range strings.Split(v, ",") {
+				hops = append(hops, strings.TrimSpace(hop))
+			}
+		}
+		if len(hops) >= proxyHops {
+			if ip := net.ParseIP(hops[len(hops)-proxyHops]); ip != nil {
+				return ip.String()
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// inviteKey — ключ ведра персональной ссылки: SHA-256 токена, чтобы рабочие ссылки
+// не лежали в rate_limits открытым текстом. Несуществующие токены вёдер не заводят —
+// такой запрос обработчик всё равно отклонит, а лимит по адресу с него уже взят.
+func (l *Limiter) inviteKey(r *http.Request) string {
+	token := peekInviteToken(r)
+	if token == "" {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), storeTimeout)
+	defer cancel()
+	if _, err := l.guests.GetGuestByToken(ctx, token); err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			log.Printf("⚠️ Не удалось проверить приглашение для ограничителя частоты: %v", err)
+		}
+		return ""
+	}
+	sum := sha256.Sum256([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// peekInviteToken достаёт invite_token из JSON-тела и возвращает тело на место для обработчика
+func peekInviteToken(r *http.Request) string {
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxPeek))
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(data), r.Body))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return ""
+	}
+	var body struct {
+		InviteToken string `json:"invite_token"`
+	}
+	if json.Unmarshal(data, &body) != nil {
+		return ""
+	}
+	return body.InviteToken
+}
+
+func tooManyRequests(w http.ResponseWriter, wait time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(map[string]string{"error": "Слишком много пожеланий подряд, попробуйте чуть позже"})
+}
@@ -0,0 +1,178 @@
+// backend/internal/ratelimit/ratelimit_test.go
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Limit
+		ok   bool
+	}{
+		{"5/10m", Limit{Burst: 5, Period: 10 * time.Minute}, true},
+		{"off", Limit{}, true},
+		{"5", Limit{}, false},
+		{"0/1m", Limit{}, false},
+		{"5/0s", Limit{}, false},
+		{"5/48h", Limit{}, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseLimit(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseLimit(%q) = %v, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		xff  string
+		hops int
+		want string
+	}{
+		{"", 0, "10.0.0.1"},
+		{"1.2.3.4", 0, "10.0.0.1"},
+		{"1.2.3.4", 1, "1.2.3.4"},
+		// Клиент подделал первый адрес — берём тот, что дописал прокси
+		{"6.6.6.6, 1.2.3.4", 1, "1.2.3.4"},
+		{"6.6.6.6, 1.2.3.4, 5.6.7.8", 2, "1.2.3.4"},
+		{"1.2.3.4", 2, "10.0.0.1"},
+		{"мусор", 1, "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.RemoteAddr = "10.0.0.1:1234"
+		if tt.xff != "" {
+			r.Header.Set("X-Forwarded-For", tt.xff)
+		}
+		if got := ClientIP(r, tt.hops); got != tt.want {
+			t.Errorf("ClientIP(%q, %d) = %q, хотели %q", tt.xff, tt.hops, got, tt.want)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	s := store.NewMemory()
+	l := New(s, s, Limit{Burst: 2, Period: time.Minute}, Limit{}, 0)
+	h := l.Wrap(func(w http.ResponseWriter, r *http.Request) {})
+
+	post := func(remote string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+		r.RemoteAddr = remote
+		w := httptest.NewRecorder()
+		h(w, r)
+		return w
+	}
+
+	for i := range 2 {
+		if w := post("10.0.0.1:1"); w.Code != http.StatusOK {
+			t.Fatalf("запрос %d: %d", i+1, w.Code)
+		}
+	}
+	w := post("10.0.0.1:1")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("третий запрос: %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("нет Retry-After")
+	}
+	if w := post("10.0.0.2:1"); w.Code != http.StatusOK {
+		t.Errorf("другой адрес: %d", w.Code)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1"
+	get := httptest.NewRecorder()
+	h(get, r)
+	if get.Code != http.StatusOK {
+		t.Errorf("GET ограничен: %d", get.Code)
+	}
+}
+
+func TestWrapInvite(t *testing.T) {
+	s := store.NewMemory()
+	ctx := context.Background()
+	for _, token := range []string{"abc", "xyz"} {
+		if err := s.CreateGuest(ctx, &models.Guest{Name: "Гость " + token, Token: token}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	l := New(s, s, Limit{Burst: 3, Period: time.Minute}, Limit{Burst: 1, Period: time.Minute}, 0)
+	var body string
+	h := l.Wrap(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	})
+
+	post := func(remote, payload string) int {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+		r.RemoteAddr = remote
+		w := httptest.NewRecorder()
+		h(w, r)
+		return w.Code
+	}
+
+	payload := `{"invite_token":"abc","message":"Горько!"}`
+	if code := post("10.0.0.1:1", payload); code != http.StatusOK {
+		t.Fatalf("первый запрос: %d", code)
+	}
+	if body != payload {
+		t.Errorf("обработчик получил тело %q", body)
+	}
+	// Повтор по той же ссылке отклоняется и не тратит лимит адреса
+	if code := post("10.0.0.1:1", payload); code != http.StatusTooManyRequests {
+		t.Errorf("повтор по тому же приглашению: %d", code)
+	}
+	if code := post("10.0.0.2:1", payload); code != http.StatusTooManyRequests {
+		t.Errorf("то же приглашение с другого адреса: %d", code)
+	}
+	if code := post("10.0.0.1:1", `{"invite_token":"xyz"}`); code != http.StatusOK {
+		t.Errorf("другое приглашение: %d", code)
+	}
+	if code := post("10.0.0.1:1", `{"message":"без приглашения"}`); code != http.StatusOK {
+		t.Errorf("без приглашения: %d", code)
+	}
+
+	// Токен не хранится в ключе ведра открытым текстом
+	if ok, _, _ := s.TakeTokens(ctx, []store.Bucket{{Key: "invite:abc", Burst: 1, Rate: 1}}); !ok {
+		t.Error("ведро приглашения названо самим токеном")
+	}
+}
+
+func TestWrapUnknownInvite(t *testing.T) {
+	s := store.NewMemory()
+	l := New(s, s, Limit{}, Limit{Burst: 1, Period: time.Minute}, 0)
+	h := l.Wrap(func(w http.ResponseWriter, r *http.Request) {})
+
+	for i := range 3 {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"invite_token":"выдумка"}`))
+		w := httptest.NewRecorder()
+		h(w, r)
+		if w.Code != http.StatusOK {
+			t.Fatalf("запрос %d: %d", i+1, w.Code)
+		}
+	}
+	// Выдуманные токены не заводят вёдер
+	ok, _, _ := s.TakeTokens(context.Background(), []store.Bucket{{Key: "invite:выдумка", Burst: 1, Rate: 1}})
+	if !ok {
+		t.Error("ведро для несуществующего приглашения")
+	}
+}
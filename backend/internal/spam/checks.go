@@ -0,0 +1,189 @@
+// backend/internal/spam/checks.go
+package spam
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"wedding-backend/internal/store"
+)
+
+// Honeypot срабатывает, если заполнено скрытое поле формы
+type Honeypot struct{}
+
+func (Honeypot) Name() string { return CheckHoneypot }
+
+func (Honeypot) Inspect(ctx context.Context, s Submission) (string, error) {
+	if strings.TrimSpace(s.Honeypot) != "" {
+		return "заполнено скрытое поле формы", nil
+	}
+	return "", nil
+}
+
+//go:embed words.txt
+var defaultWords string
+
+// pattern — стоп-слово: скелет и где он может стоять в слове
+type pattern struct {
+	word   string
+	prefix bool // * в начале: перед словом может быть что угодно
+	suffix bool // * в конце: после слова может быть что угодно
+	// latin — слово записано латиницей и не ищется в русских словах: «shit» не должно находить «шить»
+	latin  bool
+	source string
+}
+
+func (p pattern) match(s string) bool {
+	switch {
+	case p.prefix && p.suffix:
+		return strings.Contains(s, p.word)
+	case p.prefix:
+		return strings.HasSuffix(s, p.word)
+	case p.suffix:
+		return strings.HasPrefix(s, p.word)
+	}
+	return s == p.word
+}
+
+// applies — шаблон проверяется на этом скелете: латинские слова не ищутся в русских
+func (p pattern) applies(sk string, cyrillic bool) bool {
+	return (!p.latin || !cyrillic) && p.match(sk)
+}
+
+// Profanity ищет стоп-слова в имени и тексте
+type Profanity struct {
+	patterns []pattern
+	// exceptions — обычные слова, в которых прячется стоп-слово: «страхуй», «психуй»
+	exceptions []pattern
+}
+
+// NewProfanity читает список стоп-слов (формат как в words.txt); r == nil — список по умолчанию
+func NewProfanity(r io.Reader) (*Profanity, error) {
+	if r == nil {
+		r = strings.NewReader(defaultWords)
+	}
+	p := &Profanity{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// ! в начале — исключение: слово, подходящее под него, не проверяется
+		word, exception := strings.CutPrefix(line, "!")
+		pat := pattern{
+			prefix: strings.HasPrefix(word, "*"),
+			suffix: strings.HasSuffix(word, "*"),
+			latin:  !hasCyrillic(word),
+			source: line,
+		}
+		// Сам список пишется обычными буквами, поэтому цифры и латиницу в нём не подменяем
+		pat.word = skeleton(strings.ToLower(strings.Trim(word, "*")), nil)
+		if pat.word == "" {
+			return nil, fmt.Errorf("в стоп-слове %q нет букв", line)
+		}
+		if exception {
+			p.exceptions = append(p.exceptions, pat)
+		} else {
+			p.patterns = append(p.patterns, pat)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (*Profanity) Name() string { return CheckProfanity }
+
+func (p *Profanity) Inspect(ctx context.Context, s Submission) (string, error) {
+	text := s.Message
+	if !s.Invited {
+		text = s.Name + " " + text
+	}
+	for _, w := range words(text) {
+		if _, ok := find(w, p.exceptions); ok {
+			continue
+		}
+		if pat, ok := find(w, p.patterns); ok {
+			return fmt.Sprintf("стоп-слово «%s» (%s)", w, pat.source), nil
+		}
+	}
+	return "", nil
+}
+
+// find ищет первый шаблон, подходящий хотя бы к одному скелету слова
+func find(w string, patterns []pattern) (pattern, bool) {
+	cyrillic := hasCyrillic(w)
+	for _, sk := range skeletons(w) {
+		for _, pat := range patterns {
+			if pat.applies(sk, cyrillic) {
+				return pat, true
+			}
+		}
+	}
+	return pattern{}, false
+}
+
+// linkRegex — адреса сайтов, домены и ссылки на Telegram
+var linkRegex = regexp.MustCompile(`(?i)(https?://|www\.|t\.me/|` +
+	`[a-z0-9-]+\.(ru|su|com|net|org|info|biz|io|me|co|ly|xyz|top|site|online|shop|club|pro|link|click)([^a-z0-9]|$)|` +
+	`[а-яё0-9-]+\.(рф|рус)([^а-яё0-9]|$))`)
+
+// Links срабатывает на ссылки в имени или тексте
+type Links struct{}
+
+func (Links) Name() string { return CheckLinks }
+
+func (Links) Inspect(ctx context.Context, s Submission) (string, error) {
+	if m := linkRegex.FindString(s.Name + "\n" + s.Message); m != "" {
+		return fmt.Sprintf("ссылка «%s»", strings.TrimSpace(m)), nil
+	}
+	return "", nil
+}
+
+const (
+	// duplicateScan — сколько последних пожеланий сравнивать
+	duplicateScan = 500
+	// duplicateMinLength — короче этого совпадение текста считается повтором, только если совпало и имя:
+	// «Поздравляем!» могут независимо написать несколько гостей
+	duplicateMinLength = 20
+)
+
+// Duplicate ищет такое же пожелание среди отправленных за Window, включая отклонённые и удалённые
+type Duplicate struct {
+	Wishes store.WishStore
+	Window time.Duration
+}
+
+func (*Duplicate) Name() string { return CheckDuplicate }
+
+func (d *Duplicate) Inspect(ctx context.Context, s Submission) (string, error) {
+	message, name := fingerprint(s.Message), fingerprint(s.Name)
+	if message == "" {
+		return "", nil
+	}
+	recent, err := d.Wishes.List(ctx, store.ListFilter{
+		Limit: duplicateScan,
+		Since: time.Now().Add(-d.Window),
+		Trash: store.TrashInclude,
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, w := range recent {
+		if fingerprint(w.Message) != message {
+			continue
+		}
+		if len([]rune(message)) >= duplicateMinLength || fingerprint(w.Name) == name {
+			return fmt.Sprintf("повтор пожелания №%d", w.ID), nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,86 @@
+// backend/internal/spam/config.go
+package spam
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"wedding-backend/internal/store"
+)
+
+// FromEnv собирает проверки пожеланий из переменных окружения.
+// Для каждой проверки задаётся действие reject, moderate или off:
+//
+//	SPAM_HONEYPOT (reject)    — заполнено скрытое поле website
+//	SPAM_PROFANITY (moderate) — стоп-слова; SPAM_WORDS_FILE — свой список вместо встроенного
+//	SPAM_LINKS (moderate)     — ссылки и домены
+//	SPAM_DUPLICATES (reject)  — повтор пожелания за SPAM_DUPLICATE_WINDOW (24h)
+func FromEnv(wishes store.WishStore) (Pipeline, error) {
+	var pipeline Pipeline
+	add := func(key string, fallback Action, check Check) error {
+		action := fallback
+		if v := os.Getenv(key); v != "" {
+			var err error
+			if action, err = ParseAction(v); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+		}
+		pipeline = append(pipeline, Rule{Check: check, Action: action})
+		return nil
+	}
+
+	if err := add("SPAM_HONEYPOT", Reject, Honeypot{}); err != nil {
+		return nil, err
+	}
+
+	profanity, err := loadWords(os.Getenv("SPAM_WORDS_FILE"))
+	if err != nil {
+		return nil, fmt.Errorf("SPAM_WORDS_FILE: %w", err)
+	}
+	if err := add("SPAM_PROFANITY", Moderate, profanity); err != nil {
+		return nil, err
+	}
+
+	if err := add("SPAM_LINKS", Moderate, Links{}); err != nil {
+		return nil, err
+	}
+
+	window := 24 * time.Hour
+	if v := os.Getenv("SPAM_DUPLICATE_WINDOW"); v != "" {
+		if window, err = time.ParseDuration(v); err != nil || window <= 0 {
+			return nil, fmt.Errorf("SPAM_DUPLICATE_WINDOW: ожидается длительность, например 24h")
+		}
+	}
+	if err := add("SPAM_DUPLICATES", Reject, &Duplicate{Wishes: wishes, Window: window}); err != nil {
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+// String — включённые проверки для лога при старте
+func (p Pipeline) String() string {
+	var parts []string
+	for _, rule := range p {
+		if rule.Action != Allow {
+			parts = append(parts, rule.Check.Name()+"="+string(rule.Action))
+		}
+	}
+	if len(parts) == 0 {
+		return "выключены"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func loadWords(path string) (*Profanity, error) {
+	if path == "" {
+		return NewProfanity(nil)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return NewProfanity(f)
+}
@@ -0,0 +1,97 @@
+// backend/internal/spam/normalize.go
+package spam
+
+import (
+	"strings"
+	"unicode"
+)
+
+// toCyrillic — латинские буквы и цифры, похожие на кириллицу: «xyй», «п1зд», «cyka»
+var toCyrillic = map[rune]rune{
+	'a': 'а', 'b': 'в', 'c': 'с', 'e': 'е', 'h': 'н', 'k': 'к', 'm': 'м', 'n': 'п',
+	'o': 'о', 'p': 'р', 'r': 'г', 't': 'т', 'u': 'и', 'x': 'х', 'y': 'у',
+	'0': 'о', '1': 'і', '3': 'з', '4': 'ч', '6': 'б', '@': 'а',
+}
+
+// toLatin — цифры и знаки вместо латинских букв: «sh1t», «f@ck»
+var toLatin = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '@': 'a', '$': 's', '!': 'i',
+}
+
+// translit — кириллица латиницей
+var translit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'і': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n",
+	'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "h",
+	'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "sh", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e",
+	'ю': "yu", 'я': "ya",
+}
+
+// sounds сводит разные записи одного звука: «huy», «khui» и «хуй» дают одно и то же
+var sounds = strings.NewReplacer(
+	"sch", "sh", "ch", "q", "kh", "h", "ck", "k", "ph", "f",
+	"c", "k", "x", "h", "w", "v", "y", "i", "j", "i",
+)
+
+// skeletons возвращает «скелеты» слова: транслитерацию без различий в написании.
+// Вариантов два, потому что «p» в «pизд» — кириллическая р, а в «poop» — латинская.
+func skeletons(word string) []string {
+	word = strings.ToLower(word)
+	a, b := skeleton(word, toCyrillic), skeleton(word, toLatin)
+	if a == b {
+		return []string{a}
+	}
+	return []string{a, b}
+}
+
+// skeleton заменяет похожие символы по homoglyphs, отбрасывает всё, кроме букв,
+// транслитерирует и сжимает повторы («fuuuck» → «fuk»)
+func skeleton(word string, homoglyphs map[rune]rune) string {
+	var latin strings.Builder
+	for _, r := range word {
+		if h, ok := homoglyphs[r]; ok {
+			r = h
+		}
+		if t, ok := translit[r]; ok {
+			latin.WriteString(t)
+		} else if unicode.IsLetter(r) {
+			latin.WriteRune(r)
+		}
+	}
+
+	var sb strings.Builder
+	var prev rune
+	for _, r := range sounds.Replace(latin.String()) {
+		if r != prev {
+			sb.WriteRune(r)
+		}
+		prev = r
+	}
+	return sb.String()
+}
+
+func hasCyrillic(s string) bool {
+	return strings.ContainsFunc(s, func(r rune) bool { return unicode.Is(unicode.Cyrillic, r) })
+}
+
+// words делит текст на слова по пробелам: знаки внутри слова («х.у.й») не разрывают его
+func words(text string) []string {
+	return strings.Fields(text)
+}
+
+// fingerprint — текст без регистра, знаков и лишних пробелов для поиска повторов
+func fingerprint(text string) string {
+	var parts []string
+	for _, w := range words(strings.ToLower(text)) {
+		w = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, strings.ReplaceAll(w, "ё", "е"))
+		if w != "" {
+			parts = append(parts, w)
+		}
+	}
+	return strings.Join(parts, " ")
+}
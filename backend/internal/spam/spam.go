@@ -0,0 +1,98 @@
+// backend/internal/spam/spam.go
+package spam
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// Имена проверок — они же используются в логах и переменных окружения
+const (
+	CheckHoneypot  = "honeypot"
+	CheckProfanity = "profanity"
+	CheckLinks     = "links"
+	CheckDuplicate = "duplicate"
+)
+
+// Action — что делать с пожеланием, на котором сработала проверка
+type Action string
+
+const (
+	// Allow — пропустить (проверка выключена или ничего не нашла)
+	Allow Action = "off"
+	// Moderate — сохранить, но отправить на модерацию
+	Moderate Action = "moderate"
+	// Reject — не сохранять
+	Reject Action = "reject"
+)
+
+// ParseAction разбирает значение переменной окружения
+func ParseAction(s string) (Action, error) {
+	switch a := Action(s); a {
+	case Allow, Moderate, Reject:
+		return a, nil
+	}
+	return "", fmt.Errorf("ожидается reject, moderate или off: %q", s)
+}
+
+// Submission — пожелание после очистки, как его увидят гости
+type Submission struct {
+	Name    string
+	Message string
+	// Honeypot — скрытое поле формы, которое заполняют только боты
+	Honeypot string
+	// Invited — имя взято из списка гостей, а не введено в форму
+	Invited bool
+}
+
+// Check — одна проверка содержимого
+type Check interface {
+	Name() string
+	// Inspect возвращает причину срабатывания или пустую строку
+	Inspect(ctx context.Context, s Submission) (string, error)
+}
+
+// Rule — проверка и действие при её срабатывании
+type Rule struct {
+	Check  Check
+	Action Action
+}
+
+// Result — итог проверок
+type Result struct {
+	Action Action
+	// Check и Reason — какая проверка сработала и почему (для логов и уведомления модераторам)
+	Check  string
+	Reason string
+}
+
+// Pipeline — проверки по порядку; нулевое значение пропускает всё
+type Pipeline []Rule
+
+// Run прогоняет пожелание через проверки. Reject сразу прерывает проверку,
+// Moderate запоминается, но следующие проверки ещё могут отклонить пожелание.
+// Ошибка проверки только логируется: гость важнее фильтра.
+func (p Pipeline) Run(ctx context.Context, s Submission) Result {
+	result := Result{Action: Allow}
+	for _, rule := range p {
+		if rule.Action == Allow {
+			continue
+		}
+		reason, err := rule.Check.Inspect(ctx, s)
+		if err != nil {
+			log.Printf("⚠️ Проверка %s не выполнена: %v", rule.Check.Name(), err)
+			continue
+		}
+		if reason == "" {
+			continue
+		}
+		if rule.Action == Reject {
+			return Result{Action: Reject, Check: rule.Check.Name(), Reason: reason}
+		}
+		if result.Action == Allow {
+			result = Result{Action: Moderate, Check: rule.Check.Name(), Reason: reason}
+		}
+	}
+	return result
+}
@@ -0,0 +1,172 @@
+// backend/internal/spam/spam_test.go
+package spam
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/store"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func TestProfanity(t *testing.T) {
+	p, err := NewProfanity(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		text string
+		hit  bool
+	}{
+		{"Совет да любовь!", false},
+		{"иди нахуй", true},
+		{"xyй", true},
+		{"х.у.й", true},
+		{"fuuuck", true},
+		{"sh1t", true},
+		// Обычные слова, внутри которых есть стоп-слово
+		{"Подстрахуй молодых, если что", false},
+		{"Не психуй, всё будет хорошо", false},
+		{"страхуя друг друга", false},
+		// Латинское стоп-слово не ищется в русском тексте
+		{"Будем шить платье", false},
+	}
+	for _, tt := range tests {
+		reason, err := p.Inspect(context.Background(), Submission{Name: "Гость", Message: tt.text})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (reason != "") != tt.hit {
+			t.Errorf("%q: причина %q", tt.text, reason)
+		}
+	}
+}
+
+func TestProfanityCustomList(t *testing.T) {
+	if _, err := NewProfanity(strings.NewReader("***")); err == nil {
+		t.Error("стоп-слово без букв принято")
+	}
+	p, err := NewProfanity(strings.NewReader("# свой список\nкапуст*\n!капустник\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for text, hit := range map[string]bool{"капуста": true, "капустник": false} {
+		reason, _ := p.Inspect(context.Background(), Submission{Message: text})
+		if (reason != "") != hit {
+			t.Errorf("%q: причина %q", text, reason)
+		}
+	}
+}
+
+func TestLinks(t *testing.T) {
+	for text, hit := range map[string]bool{
+		"https://example.com":      true,
+		"пишите в t.me/spam":       true,
+		"заходите на сайт.рф":      true,
+		"Горько! Ура.Счастья вам.": false,
+	} {
+		reason, _ := Links{}.Inspect(context.Background(), Submission{Message: text})
+		if (reason != "") != hit {
+			t.Errorf("%q: причина %q", text, reason)
+		}
+	}
+}
+
+func TestDuplicate(t *testing.T) {
+	s := store.NewMemory()
+	ctx := context.Background()
+	long := "Желаем вам долгих лет счастья и любви!"
+	for _, w := range []models.Wish{{Name: "Аня", Message: long}, {Name: "Петя", Message: "Поздравляем!"}} {
+		if err := s.Create(ctx, &w, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := &Duplicate{Wishes: s, Window: time.Hour}
+	tests := []struct {
+		name, message string
+		hit           bool
+	}{
+		{"Оля", "желаем вам, долгих лет счастья и любви", true},
+		{"Оля", "Поздравляем!", false},
+		{"петя", "поздравляем", true},
+		{"Оля", "Совсем другое пожелание", false},
+	}
+	for _, tt := range tests {
+		reason, err := d.Inspect(ctx, Submission{Name: tt.name, Message: tt.message})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (reason != "") != tt.hit {
+			t.Errorf("%s: %q: причина %q", tt.name, tt.message, reason)
+		}
+	}
+}
+
+// stub срабатывает всегда или возвращает ошибку
+type stub struct {
+	name string
+	err  error
+}
+
+func (s stub) Name() string { return s.name }
+
+func (s stub) Inspect(ctx context.Context, sub Submission) (string, error) {
+	return "сработала " + s.name, s.err
+}
+
+func TestPipeline(t *testing.T) {
+	ctx := context.Background()
+	spam := Submission{Name: "Бот", Message: "Купите", Honeypot: "http://spam"}
+
+	if got := (Pipeline{}).Run(ctx, spam); got.Action != Allow {
+		t.Errorf("пустой конвейер: %+v", got)
+	}
+
+	p := Pipeline{
+		{Check: stub{name: "broken", err: errors.New("нет связи")}, Action: Reject},
+		{Check: stub{name: "first"}, Action: Moderate},
+		{Check: stub{name: "second"}, Action: Moderate},
+	}
+	if got := p.Run(ctx, spam); got.Action != Moderate || got.Check != "first" {
+		t.Errorf("модерация: %+v", got)
+	}
+
+	p = append(p, Rule{Check: Honeypot{}, Action: Reject})
+	if got := p.Run(ctx, spam); got.Action != Reject || got.Check != CheckHoneypot {
+		t.Errorf("отклонение после модерации: %+v", got)
+	}
+
+	spam.Honeypot = " "
+	if got := p.Run(ctx, spam); got.Action != Moderate {
+		t.Errorf("пробел в скрытом поле: %+v", got)
+	}
+
+	p[1].Action = Allow
+	p[2].Action = Allow
+	if got := p.Run(ctx, spam); got.Action != Allow {
+		t.Errorf("выключенные проверки: %+v", got)
+	}
+}
+
+func TestParseAction(t *testing.T) {
+	for _, s := range []string{"reject", "moderate", "off"} {
+		if _, err := ParseAction(s); err != nil {
+			t.Errorf("%q: %v", s, err)
+		}
+	}
+	if _, err := ParseAction("block"); err == nil {
+		t.Error("неизвестное действие принято")
+	}
+}
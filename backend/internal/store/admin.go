@@ -0,0 +1,20 @@
+// backend/internal/store/admin.go
+package store
+
+import (
+	"context"
+
+	"wedding-backend/internal/models"
+)
+
+// AdminStore — администраторы бота, добавленные через /admin
+type AdminStore interface {
+	// ListAdmins возвращает администраторов в порядке добавления
+	ListAdmins(ctx context.Context) ([]models.Admin, error)
+	// GetAdmin возвращает администратора по chat ID или ErrNotFound
+	GetAdmin(ctx context.Context, chatID int64) (models.Admin, error)
+	// SaveAdmin добавляет администратора или меняет роль и имя существующего
+	SaveAdmin(ctx context.Context, admin *models.Admin) error
+	// DeleteAdmin удаляет администратора, возвращает false, если его не было
+	DeleteAdmin(ctx context.Context, chatID int64) (bool, error)
+}
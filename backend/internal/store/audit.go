@@ -0,0 +1,24 @@
+// backend/internal/store/audit.go
+package store
+
+import (
+	"context"
+
+	"wedding-backend/internal/models"
+)
+
+// AuditStore — журнал действий администраторов
+type AuditStore interface {
+	// AddAudit записывает событие и заполняет ID и CreatedAt
+	AddAudit(ctx context.Context, event *models.AuditEvent) error
+	// ListAudit возвращает события по фильтру, новые первыми
+	ListAudit(ctx context.Context, filter AuditFilter) ([]models.AuditEvent, error)
+}
+
+// AuditFilter — условия выборки журнала. Нулевое значение — весь журнал.
+type AuditFilter struct {
+	// WishID — только события, затронувшие это пожелание
+	WishID int
+	// Limit — максимум записей, 0 — без ограничения
+	Limit int
+}
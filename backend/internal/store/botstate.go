@@ -0,0 +1,22 @@
+// backend/internal/store/botstate.go
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// BotStateStore — служебное состояние Telegram-бота
+type BotStateStore interface {
+	// TelegramOffset возвращает сохранённый offset getUpdates (0, если его ещё нет)
+	TelegramOffset(ctx context.Context) (int, error)
+	// SaveTelegramOffset запоминает offset, чтобы после перезапуска не обработать обновления повторно
+	SaveTelegramOffset(ctx context.Context, offset int) error
+	// LastBackupAt возвращает время последней резервной копии (нулевое, если её не было)
+	LastBackupAt(ctx context.Context) (time.Time, error)
+	// SaveLastBackupAt запоминает время резервной копии, чтобы перезапуск не сбивал расписание
+	SaveLastBackupAt(ctx context.Context, at time.Time) error
+	// WithBackupLock выполняет fn, если копию сейчас не снимает другой экземпляр;
+	// false — блокировка занята и fn не вызывалась
+	WithBackupLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error)
+}
@@ -0,0 +1,105 @@
+// backend/internal/store/filter.go
+package store
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+// ErrBadCursor — курсор не удалось разобрать
+var ErrBadCursor = errors.New("invalid cursor")
+
+// Cursor — позиция в ленте пожеланий: пара (created_at, id) однозначно задаёт порядок
+type Cursor struct {
+	CreatedAt time.Time
+	ID        int
+}
+
+// String кодирует курсор в непрозрачную строку для query-параметров
+func (c Cursor) String() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + strconv.Itoa(c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor разбирает строку, полученную из Cursor.String
+func ParseCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrBadCursor
+	}
+	ts, idStr, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return Cursor{}, ErrBadCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return Cursor{}, ErrBadCursor
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		return Cursor{}, ErrBadCursor
+	}
+	return Cursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// ListFilter — условия выборки для WishStore.List. Нулевое значение — все пожелания вне корзины.
+// Результат всегда отсортирован от новых к старым.
+type ListFilter struct {
+	// Limit — максимум записей, 0 — без ограничения
+	Limit int
+	// Before — только записи старше курсора (следующая страница)
+	Before *Cursor
+	// After — только записи новее курсора (ближайшие к нему, если задан Limit)
+	After *Cursor
+	// Since — только записи, созданные не раньше этого момента
+	Since time.Time
+	// Query — подстрока в имени или тексте, без учёта регистра
+	Query string
+	// Status — только пожелания с этим статусом модерации, пусто — любые
+	Status string
+	// Trash — как быть с пожеланиями из корзины; по умолчанию они не попадают в выборку
+	Trash TrashMode
+}
+
+// TrashMode — отбор по корзине в ListFilter
+type TrashMode int
+
+const (
+	// TrashExclude — только неудалённые пожелания
+	TrashExclude TrashMode = iota
+	// TrashOnly — только пожелания из корзины
+	TrashOnly
+	// TrashInclude — все пожелания, включая корзину (бэкапы)
+	TrashInclude
+)
+
+// compare сравнивает позицию (t, id) с курсором: -1 — старше, 0 — совпадает, 1 — новее
+func (c Cursor) compare(t time.Time, id int) int {
+	switch {
+	case t.Before(c.CreatedAt):
+		return -1
+	case t.After(c.CreatedAt):
+		return 1
+	case id < c.ID:
+		return -1
+	case id > c.ID:
+		return 1
+	}
+	return 0
+}
+
+// matches проверяет пожелание на соответствие режиму (для Memory)
+func (t TrashMode) matches(w models.Wish) bool {
+	switch t {
+	case TrashOnly:
+		return w.DeletedAt != nil
+	case TrashInclude:
+		return true
+	}
+	return w.DeletedAt == nil
+}
@@ -0,0 +1,81 @@
+// backend/internal/store/filter_test.go
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	c := Cursor{CreatedAt: time.Date(2025, 7, 12, 18, 30, 0, 123456789, time.FixedZone("MSK", 3*3600)), ID: 42}
+	got, err := ParseCursor(c.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.CreatedAt.Equal(c.CreatedAt) || got.ID != c.ID {
+		t.Errorf("получили %+v, ожидали %+v", got, c)
+	}
+}
+
+func TestParseCursorInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"не base64",
+		Cursor{}.String()[:4],
+		encode("2025-07-12T18:30:00Z"),
+		encode("вчера|5"),
+		encode("2025-07-12T18:30:00Z|0"),
+		encode("2025-07-12T18:30:00Z|x"),
+	} {
+		if _, err := ParseCursor(s); !errors.Is(err, ErrBadCursor) {
+			t.Errorf("ParseCursor(%q) = %v, ожидали ErrBadCursor", s, err)
+		}
+	}
+}
+
+// encode кодирует произвольную строку так же, как Cursor.String
+func encode(raw string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func TestMemoryListCursor(t *testing.T) {
+	m := NewMemory()
+	ctx := context.Background()
+	for _, name := range []string{"1", "2", "3", "4"} {
+		if err := m.Create(ctx, &models.Wish{Name: name, Message: "Ура"}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	all, err := m.List(ctx, ListFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(i int) *Cursor { return &Cursor{CreatedAt: all[i].CreatedAt, ID: all[i].ID} }
+
+	names := func(f ListFilter) string {
+		t.Helper()
+		list, err := m.List(ctx, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := ""
+		for _, w := range list {
+			s += w.Name
+		}
+		return s
+	}
+
+	// Курсор сам в выборку не попадает
+	if got := names(ListFilter{Before: at(1), Limit: 2}); got != "21" {
+		t.Errorf("before: %q", got)
+	}
+	// after с лимитом берёт ближайшие к курсору записи, но отдаёт их от новых к старым
+	if got := names(ListFilter{After: at(3), Limit: 2}); got != "32" {
+		t.Errorf("after: %q", got)
+	}
+}
@@ -0,0 +1,22 @@
+// backend/internal/store/guest.go
+package store
+
+import (
+	"context"
+
+	"wedding-backend/internal/models"
+)
+
+// GuestStore — хранилище приглашённых гостей
+type GuestStore interface {
+	// ListGuests возвращает всех гостей по алфавиту
+	ListGuests(ctx context.Context) ([]models.Guest, error)
+	// GetGuest возвращает гостя по ID или ErrNotFound
+	GetGuest(ctx context.Context, id int) (models.Guest, error)
+	// GetGuestByToken возвращает гостя по токену приглашения или ErrNotFound
+	GetGuestByToken(ctx context.Context, token string) (models.Guest, error)
+	// CreateGuest сохраняет гостя (Token задаёт вызывающий) и заполняет ID и CreatedAt
+	CreateGuest(ctx context.Context, guest *models.Guest) error
+	// SaveGuests в одной транзакции создаёт гостей с ID == 0 и обновляет остальных по ID
+	SaveGuests(ctx context.Context, guests []models.Guest) error
+}
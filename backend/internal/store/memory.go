@@ -0,0 +1,247 @@
+// backend/internal/store/memory.go
+package store
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+// Memory — реализация WishStore в памяти (для тестов и локального запуска без БД)
+type Memory struct {
+	mu     sync.RWMutex
+	wishes map[int]models.Wish
+	nextID int
+
+	rsvps      map[string]models.RSVP
+	nextRSVPID int
+
+	guests      map[int]models.Guest
+	nextGuestID int
+
+	outbox       map[int]models.OutboxEntry
+	nextOutboxID int
+
+	telegramOffset int
+	lastBackup     time.Time
+	backupMu       sync.Mutex
+
+	admins map[int64]models.Admin
+
+	audit       []models.AuditEvent
+	nextAuditID int64
+
+	buckets map[string]tokenBucket
+}
+
+// NewMemory создаёт пустое хранилище в памяти
+func NewMemory() *Memory {
+	return &Memory{
+		wishes: make(map[int]models.Wish),
+		nextID: 1,
+		rsvps:  make(map[string]models.RSVP),
+		guests: make(map[int]models.Guest),
+		outbox: make(map[int]models.OutboxEntry),
+		admins: make(map[int64]models.Admin),
+
+		buckets: make(map[string]tokenBucket),
+	}
+}
+
+func (m *Memory) List(ctx context.Context, filter ListFilter) ([]models.Wish, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	query := strings.ToLower(filter.Query)
+	wishes := make([]models.Wish, 0, len(m.wishes))
+	for _, w := range m.wishes {
+		if filter.Before != nil && filter.Before.compare(w.CreatedAt, w.ID) >= 0 {
+			continue
+		}
+		if filter.After != nil && filter.After.compare(w.CreatedAt, w.ID) <= 0 {
+			continue
+		}
+		if !filter.Since.IsZero() && w.CreatedAt.Before(filter.Since) {
+			continue
+		}
+		if filter.Status != "" && w.Status != filter.Status {
+			continue
+		}
+		if !filter.Trash.matches(w) {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(w.Name), query) &&
+			!strings.Contains(strings.ToLower(w.Message), query) {
+			continue
+		}
+		wishes = append(wishes, w)
+	}
+	sort.Slice(wishes, func(i, j int) bool {
+		if !wishes[i].CreatedAt.Equal(wishes[j].CreatedAt) {
+			return wishes[i].CreatedAt.After(wishes[j].CreatedAt)
+		}
+		return wishes[i].ID > wishes[j].ID
+	})
+
+	if filter.Limit > 0 && len(wishes) > filter.Limit {
+		if filter.After != nil {
+			// Для After нужны записи, ближайшие к курсору, — они в конце списка
+			wishes = wishes[len(wishes)-filter.Limit:]
+		} else {
+			wishes = wishes[:filter.Limit]
+		}
+	}
+	return wishes, nil
+}
+
+func (m *Memory) Get(ctx context.Context, id int) (models.Wish, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	w, ok := m.wishes[id]
+	if !ok || w.DeletedAt != nil {
+		return models.Wish{}, ErrNotFound
+	}
+	return w, nil
+}
+
+func (m *Memory) Create(ctx context.Context, wish *models.Wish, outbox OutboxFunc) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	saved := *wish
+	saved.ID = m.nextID
+	saved.Status = statusOrDefault(saved.Status)
+	saved.CreatedAt = time.Now()
+
+	// Как и в Postgres, ошибка построения уведомлений отменяет сохранение
+	if outbox != nil {
+		entries, err := outbox(saved)
+		if err != nil {
+			return err
+		}
+		m.enqueueLocked(entries)
+	}
+
+	m.nextID++
+	m.wishes[saved.ID] = saved
+	*wish = saved
+	return nil
+}
+
+func (m *Memory) SetStatus(ctx context.Context, id int, status string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.wishes[id]
+	if !ok || w.DeletedAt != nil {
+		return ErrNotFound
+	}
+	w.Status = status
+	m.wishes[id] = w
+	return nil
+}
+
+func (m *Memory) Delete(ctx context.Context, id int) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.wishes[id]
+	if !ok || w.DeletedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	w.DeletedAt = &now
+	m.wishes[id] = w
+	return true, nil
+}
+
+func (m *Memory) DeleteAll(ctx context.Context) (int64, time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Как в Postgres — с точностью до микросекунд, ими кодируется кнопка отмены
+	at := time.Now().Truncate(time.Microsecond)
+	var n int64
+	for id, w := range m.wishes {
+		if w.DeletedAt == nil {
+			w.DeletedAt = &at
+			m.wishes[id] = w
+			n++
+		}
+	}
+	return n, at, nil
+}
+
+func (m *Memory) Undelete(ctx context.Context, id int) (models.Wish, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.wishes[id]
+	if !ok || w.DeletedAt == nil {
+		return models.Wish{}, ErrNotFound
+	}
+	w.DeletedAt = nil
+	m.wishes[id] = w
+	return w, nil
+}
+
+func (m *Memory) UndeleteAll(ctx context.Context, at time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var n int64
+	for id, w := range m.wishes {
+		if w.DeletedAt != nil && w.DeletedAt.Equal(at) {
+			w.DeletedAt = nil
+			m.wishes[id] = w
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *Memory) Purge(ctx context.Context, before time.Time) ([]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var ids []int
+	for id, w := range m.wishes {
+		if w.DeletedAt != nil && w.DeletedAt.Before(before) {
+			delete(m.wishes, id)
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+func (m *Memory) Restore(ctx context.Context, wishes []models.Wish, remove []int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range remove {
+		delete(m.wishes, id)
+	}
+	for _, w := range wishes {
+		w.Status = statusOrDefault(w.Status)
+		// Как и в Postgres, ссылку на удалённого гостя не сохраняем
+		if w.GuestID != nil {
+			if _, ok := m.guests[*w.GuestID]; !ok {
+				w.GuestID = nil
+			}
+		}
+		m.wishes[w.ID] = w
+	}
+
+	// Как setval в Postgres: новые ID продолжаются с максимального
+	m.nextID = 1
+	for id := range m.wishes {
+		m.nextID = max(m.nextID, id+1)
+	}
+	return len(wishes), nil
+}
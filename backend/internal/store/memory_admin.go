@@ -0,0 +1,60 @@
+// backend/internal/store/memory_admin.go
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+func (m *Memory) ListAdmins(ctx context.Context) ([]models.Admin, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	admins := make([]models.Admin, 0, len(m.admins))
+	for _, a := range m.admins {
+		admins = append(admins, a)
+	}
+	sort.Slice(admins, func(i, j int) bool {
+		if !admins[i].CreatedAt.Equal(admins[j].CreatedAt) {
+			return admins[i].CreatedAt.Before(admins[j].CreatedAt)
+		}
+		return admins[i].ChatID < admins[j].ChatID
+	})
+	return admins, nil
+}
+
+func (m *Memory) GetAdmin(ctx context.Context, chatID int64) (models.Admin, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	a, ok := m.admins[chatID]
+	if !ok {
+		return models.Admin{}, ErrNotFound
+	}
+	return a, nil
+}
+
+func (m *Memory) SaveAdmin(ctx context.Context, admin *models.Admin) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := m.admins[admin.ChatID]; ok {
+		admin.CreatedAt = existing.CreatedAt
+	} else {
+		admin.CreatedAt = time.Now()
+	}
+	m.admins[admin.ChatID] = *admin
+	return nil
+}
+
+func (m *Memory) DeleteAdmin(ctx context.Context, chatID int64) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.admins[chatID]
+	delete(m.admins, chatID)
+	return ok, nil
+}
@@ -0,0 +1,39 @@
+// backend/internal/store/memory_audit.go
+package store
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+func (m *Memory) AddAudit(ctx context.Context, event *models.AuditEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextAuditID++
+	event.ID = m.nextAuditID
+	event.CreatedAt = time.Now()
+	m.audit = append(m.audit, *event)
+	return nil
+}
+
+func (m *Memory) ListAudit(ctx context.Context, filter AuditFilter) ([]models.AuditEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var events []models.AuditEvent
+	for i := len(m.audit) - 1; i >= 0; i-- {
+		e := m.audit[i]
+		if filter.WishID > 0 && !slices.Contains(e.WishIDs, filter.WishID) {
+			continue
+		}
+		events = append(events, e)
+		if filter.Limit > 0 && len(events) == filter.Limit {
+			break
+		}
+	}
+	return events, nil
+}
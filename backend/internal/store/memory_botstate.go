@@ -0,0 +1,45 @@
+// backend/internal/store/memory_botstate.go
+package store
+
+import (
+	"context"
+	"time"
+)
+
+func (m *Memory) TelegramOffset(ctx context.Context) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.telegramOffset, nil
+}
+
+func (m *Memory) SaveTelegramOffset(ctx context.Context, offset int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.telegramOffset = offset
+	return nil
+}
+
+func (m *Memory) LastBackupAt(ctx context.Context) (time.Time, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.lastBackup, nil
+}
+
+func (m *Memory) SaveLastBackupAt(ctx context.Context, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastBackup = at
+	return nil
+}
+
+func (m *Memory) WithBackupLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	if !m.backupMu.TryLock() {
+		return false, nil
+	}
+	defer m.backupMu.Unlock()
+	return true, fn(ctx)
+}
@@ -0,0 +1,81 @@
+// backend/internal/store/memory_guest.go
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+func (m *Memory) ListGuests(ctx context.Context) ([]models.Guest, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	guests := make([]models.Guest, 0, len(m.guests))
+	for _, g := range m.guests {
+		guests = append(guests, g)
+	}
+	sort.Slice(guests, func(i, j int) bool {
+		if guests[i].Name != guests[j].Name {
+			return guests[i].Name < guests[j].Name
+		}
+		return guests[i].ID < guests[j].ID
+	})
+	return guests, nil
+}
+
+func (m *Memory) GetGuest(ctx context.Context, id int) (models.Guest, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	g, ok := m.guests[id]
+	if !ok {
+		return models.Guest{}, ErrNotFound
+	}
+	return g, nil
+}
+
+func (m *Memory) GetGuestByToken(ctx context.Context, token string) (models.Guest, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, g := range m.guests {
+		if g.Token == token {
+			return g, nil
+		}
+	}
+	return models.Guest{}, ErrNotFound
+}
+
+func (m *Memory) CreateGuest(ctx context.Context, guest *models.Guest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextGuestID++
+	guest.ID = m.nextGuestID
+	guest.CreatedAt = time.Now()
+	m.guests[guest.ID] = *guest
+	return nil
+}
+
+func (m *Memory) SaveGuests(ctx context.Context, guests []models.Guest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range guests {
+		g := &guests[i]
+		if g.ID == 0 {
+			m.nextGuestID++
+			g.ID = m.nextGuestID
+			g.CreatedAt = time.Now()
+		} else if old, ok := m.guests[g.ID]; ok {
+			g.CreatedAt = old.CreatedAt
+		} else {
+			return ErrNotFound
+		}
+		m.guests[g.ID] = *g
+	}
+	return nil
+}
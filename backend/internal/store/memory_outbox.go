@@ -0,0 +1,115 @@
+// backend/internal/store/memory_outbox.go
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+// enqueueLocked добавляет уведомления; вызывается под m.mu
+func (m *Memory) enqueueLocked(entries []models.OutboxEntry) {
+	now := time.Now()
+	for _, e := range entries {
+		m.nextOutboxID++
+		e.ID = m.nextOutboxID
+		e.Status = models.OutboxPending
+		e.Attempts = 0
+		e.NextAttemptAt = now
+		e.CreatedAt = now
+		m.outbox[e.ID] = e
+	}
+}
+
+func (m *Memory) EnqueueOutbox(ctx context.Context, entries []models.OutboxEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.enqueueLocked(entries)
+	return nil
+}
+
+func (m *Memory) ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	var due []models.OutboxEntry
+	for _, e := range m.outbox {
+		if e.Status == models.OutboxPending && !e.NextAttemptAt.After(now) {
+			due = append(due, e)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	for _, e := range due {
+		e.NextAttemptAt = now.Add(lease)
+		m.outbox[e.ID] = e
+	}
+	return due, nil
+}
+
+func (m *Memory) MarkOutboxSent(ctx context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.outbox[id]; ok {
+		e.Status = models.OutboxSent
+		e.Attempts++
+		e.LastError = ""
+		m.outbox[id] = e
+	}
+	return nil
+}
+
+func (m *Memory) MarkOutboxFailed(ctx context.Context, id int, lastErr string, next time.Time, dead bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.outbox[id]; ok {
+		e.Attempts++
+		e.LastError = lastErr
+		e.NextAttemptAt = next
+		if dead {
+			e.Status = models.OutboxDead
+		}
+		m.outbox[id] = e
+	}
+	return nil
+}
+
+func (m *Memory) ListOutbox(ctx context.Context, status string, limit int) ([]models.OutboxEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var entries []models.OutboxEntry
+	for _, e := range m.outbox {
+		if e.Status == status {
+			entries = append(entries, e)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID > entries[j].ID })
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+func (m *Memory) RetryOutbox(ctx context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.outbox[id]
+	if !ok || e.Status != models.OutboxDead {
+		return ErrNotFound
+	}
+	e.Status = models.OutboxPending
+	e.Attempts = 0
+	e.NextAttemptAt = time.Now()
+	m.outbox[id] = e
+	return nil
+}
@@ -0,0 +1,55 @@
+// backend/internal/store/memory_ratelimit.go
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// tokenBucket — ведро в памяти
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func (m *Memory) TakeTokens(ctx context.Context, buckets []Bucket) (bool, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	refilled := make([]tokenBucket, len(buckets))
+	var wait time.Duration
+	for i, bucket := range buckets {
+		b, ok := m.buckets[bucket.Key]
+		if !ok {
+			b = tokenBucket{tokens: float64(bucket.Burst)}
+		} else {
+			b.tokens = min(float64(bucket.Burst), b.tokens+now.Sub(b.updated).Seconds()*bucket.Rate)
+		}
+		b.updated = now
+		if b.tokens < 1 {
+			wait = max(wait, tokenWait(b.tokens, bucket.Rate))
+		}
+		refilled[i] = b
+	}
+
+	for i, bucket := range buckets {
+		if wait == 0 {
+			refilled[i].tokens--
+		}
+		m.buckets[bucket.Key] = refilled[i]
+	}
+	return wait == 0, wait, nil
+}
+
+func (m *Memory) PruneTokens(ctx context.Context, before time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, b := range m.buckets {
+		if b.updated.Before(before) {
+			delete(m.buckets, key)
+		}
+	}
+	return nil
+}
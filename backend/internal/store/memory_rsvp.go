@@ -0,0 +1,73 @@
+// backend/internal/store/memory_rsvp.go
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+func (m *Memory) ListRSVPs(ctx context.Context) ([]models.RSVP, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rsvps := make([]models.RSVP, 0, len(m.rsvps))
+	for _, r := range m.rsvps {
+		rsvps = append(rsvps, r)
+	}
+	sort.Slice(rsvps, func(i, j int) bool { return rsvps[i].ID > rsvps[j].ID })
+	return rsvps, nil
+}
+
+func (m *Memory) GetRSVP(ctx context.Context, token string) (models.RSVP, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	r, ok := m.rsvps[token]
+	if !ok {
+		return models.RSVP{}, ErrNotFound
+	}
+	return r, nil
+}
+
+func (m *Memory) GetRSVPByGuest(ctx context.Context, guestID int) (models.RSVP, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, r := range m.rsvps {
+		if r.GuestID != nil && *r.GuestID == guestID {
+			return r, nil
+		}
+	}
+	return models.RSVP{}, ErrNotFound
+}
+
+func (m *Memory) CreateRSVP(ctx context.Context, rsvp *models.RSVP) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextRSVPID++
+	rsvp.ID = m.nextRSVPID
+	rsvp.CreatedAt = time.Now()
+	rsvp.UpdatedAt = rsvp.CreatedAt
+	m.rsvps[rsvp.Token] = *rsvp
+	return nil
+}
+
+func (m *Memory) UpdateRSVP(ctx context.Context, rsvp *models.RSVP) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old, ok := m.rsvps[rsvp.Token]
+	if !ok {
+		return ErrNotFound
+	}
+	rsvp.ID = old.ID
+	rsvp.GuestID = old.GuestID
+	rsvp.CreatedAt = old.CreatedAt
+	rsvp.UpdatedAt = time.Now()
+	m.rsvps[rsvp.Token] = *rsvp
+	return nil
+}
@@ -0,0 +1,31 @@
+// backend/internal/store/outbox.go
+package store
+
+import (
+	"context"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+// OutboxFunc строит уведомления о только что сохранённой записи;
+// они пишутся в outbox в той же транзакции, что и сама запись
+type OutboxFunc func(wish models.Wish) ([]models.OutboxEntry, error)
+
+// OutboxStore — очередь уведомлений с повторными попытками доставки
+type OutboxStore interface {
+	// EnqueueOutbox ставит уведомления в очередь
+	EnqueueOutbox(ctx context.Context, entries []models.OutboxEntry) error
+	// ClaimOutbox забирает до limit уведомлений, которым пора доставляться,
+	// и на lease скрывает их от других экземпляров
+	ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEntry, error)
+	// MarkOutboxSent отмечает уведомление доставленным
+	MarkOutboxSent(ctx context.Context, id int) error
+	// MarkOutboxFailed записывает неудачную попытку: следующая — в next,
+	// а при dead уведомление больше не доставляется
+	MarkOutboxFailed(ctx context.Context, id int, lastErr string, next time.Time, dead bool) error
+	// ListOutbox возвращает последние уведомления со статусом status
+	ListOutbox(ctx context.Context, status string, limit int) ([]models.OutboxEntry, error)
+	// RetryOutbox возвращает недоставленное уведомление в очередь, ErrNotFound — если его нет
+	RetryOutbox(ctx context.Context, id int) error
+}
@@ -0,0 +1,268 @@
+// backend/internal/store/postgres.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"wedding-backend/internal/models"
+)
+
+// likeEscaper экранирует спецсимволы LIKE в пользовательском запросе
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// wishColumns — столбцы, которые читает scanWish, в том же порядке
+const wishColumns = "id, name, message, status, guest_id, created_at, deleted_at"
+
+// rowScanner — общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWish(row rowScanner) (models.Wish, error) {
+	var w models.Wish
+	var guestID sql.NullInt64
+	var deletedAt sql.NullTime
+	err := row.Scan(&w.ID, &w.Name, &w.Message, &w.Status, &guestID, &w.CreatedAt, &deletedAt)
+	w.GuestID = intPtr(guestID)
+	if deletedAt.Valid {
+		w.DeletedAt = &deletedAt.Time
+	}
+	return w, err
+}
+
+// intPtr превращает NULL-столбец в nil
+func intPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int64)
+	return &n
+}
+
+// Postgres — реализация WishStore поверх PostgreSQL
+type Postgres struct {
+	db *sql.DB
+}
+
+// NewPostgres создаёт хранилище поверх открытого подключения
+func NewPostgres(db *sql.DB) *Postgres {
+	return &Postgres{db: db}
+}
+
+func (p *Postgres) List(ctx context.Context, filter ListFilter) ([]models.Wish, error) {
+	var where []string
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Before != nil {
+		where = append(where, fmt.Sprintf("(created_at, id) < (%s, %s)", arg(filter.Before.CreatedAt), arg(filter.Before.ID)))
+	}
+	if filter.After != nil {
+		where = append(where, fmt.Sprintf("(created_at, id) > (%s, %s)", arg(filter.After.CreatedAt), arg(filter.After.ID)))
+	}
+	if !filter.Since.IsZero() {
+		where = append(where, "created_at >= "+arg(filter.Since))
+	}
+	if filter.Status != "" {
+		where = append(where, "status = "+arg(filter.Status))
+	}
+	switch filter.Trash {
+	case TrashExclude:
+		where = append(where, "deleted_at IS NULL")
+	case TrashOnly:
+		where = append(where, "deleted_at IS NOT NULL")
+	}
+	if filter.Query != "" {
+		pattern := arg("%" + likeEscaper.Replace(filter.Query) + "%")
+		where = append(where, fmt.Sprintf("(name ILIKE %s OR message ILIKE %s)", pattern, pattern))
+	}
+
+	query := "SELECT " + wishColumns + " FROM wishes"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	// Для After берём ближайшие к курсору записи, а потом разворачиваем
+	ascending := filter.After != nil && filter.Limit > 0
+	if ascending {
+		query += " ORDER BY created_at ASC, id ASC"
+	} else {
+		query += " ORDER BY created_at DESC, id DESC"
+	}
+	if filter.Limit > 0 {
+		query += " LIMIT " + arg(filter.Limit)
+	}
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var wishes []models.Wish
+	for rows.Next() {
+		w, err := scanWish(rows)
+		if err != nil {
+			return nil, err
+		}
+		wishes = append(wishes, w)
+	}
+	if ascending {
+		slices.Reverse(wishes)
+	}
+	return wishes, rows.Err()
+}
+
+func (p *Postgres) Get(ctx context.Context, id int) (models.Wish, error) {
+	w, err := scanWish(p.db.QueryRowContext(ctx,
+		"SELECT "+wishColumns+" FROM wishes WHERE id = $1 AND deleted_at IS NULL", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return w, ErrNotFound
+	}
+	return w, err
+}
+
+func (p *Postgres) Create(ctx context.Context, wish *models.Wish, outbox OutboxFunc) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	wish.Status = statusOrDefault(wish.Status)
+	err = tx.QueryRowContext(ctx,
+		"INSERT INTO wishes (name, message, status, guest_id) VALUES ($1, $2, $3, $4) RETURNING id, created_at",
+		wish.Name, wish.Message, wish.Status, wish.GuestID,
+	).Scan(&wish.ID, &wish.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	if outbox != nil {
+		entries, err := outbox(*wish)
+		if err != nil {
+			return err
+		}
+		if err := insertOutbox(ctx, tx, entries); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (p *Postgres) SetStatus(ctx context.Context, id int, status string) error {
+	res, err := p.db.ExecContext(ctx, "UPDATE wishes SET status = $1 WHERE id = $2 AND deleted_at IS NULL", status, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err == nil && n == 0 {
+		err = ErrNotFound
+	}
+	return err
+}
+
+func (p *Postgres) Delete(ctx context.Context, id int) (bool, error) {
+	res, err := p.db.ExecContext(ctx, "UPDATE wishes SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL", id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (p *Postgres) DeleteAll(ctx context.Context) (int64, time.Time, error) {
+	// Postgres хранит микросекунды: так UndeleteAll найдёт строки по точному совпадению
+	at := time.Now().UTC().Truncate(time.Microsecond)
+	res, err := p.db.ExecContext(ctx, "UPDATE wishes SET deleted_at = $1 WHERE deleted_at IS NULL", at)
+	if err != nil {
+		return 0, at, err
+	}
+	n, err := res.RowsAffected()
+	return n, at, err
+}
+
+func (p *Postgres) Undelete(ctx context.Context, id int) (models.Wish, error) {
+	w, err := scanWish(p.db.QueryRowContext(ctx,
+		"UPDATE wishes SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL RETURNING "+wishColumns, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return w, ErrNotFound
+	}
+	return w, err
+}
+
+func (p *Postgres) UndeleteAll(ctx context.Context, at time.Time) (int64, error) {
+	res, err := p.db.ExecContext(ctx, "UPDATE wishes SET deleted_at = NULL WHERE deleted_at = $1", at)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (p *Postgres) Purge(ctx context.Context, before time.Time) ([]int, error) {
+	rows, err := p.db.QueryContext(ctx, "DELETE FROM wishes WHERE deleted_at < $1 RETURNING id", before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (p *Postgres) Restore(ctx context.Context, wishes []models.Wish, remove []int) (int, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if len(remove) > 0 {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM wishes WHERE id = ANY($1)", pq.Array(remove)); err != nil {
+			return 0, err
+		}
+	}
+
+	// guest_id из бэкапа сохраняем, только если такой гость ещё существует
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO wishes (id, name, message, status, created_at, guest_id, deleted_at)
+		VALUES ($1, $2, $3, $4, $5, (SELECT id FROM guests WHERE id = $6), $7)
+		ON CONFLICT (id) DO UPDATE SET name = $2, message = $3, status = $4, created_at = $5,
+			guest_id = EXCLUDED.guest_id, deleted_at = $7`)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	for _, w := range wishes {
+		if _, err := stmt.ExecContext(ctx, w.ID, w.Name, w.Message, statusOrDefault(w.Status), w.CreatedAt, w.GuestID, w.DeletedAt); err != nil {
+			return 0, err
+		}
+	}
+
+	// INSERT с явным id не двигает SERIAL: без этого следующий AddWish получил бы занятый ID
+	if _, err := tx.ExecContext(ctx, `SELECT setval(pg_get_serial_sequence('wishes', 'id'), COALESCE((SELECT MAX(id) FROM wishes), 0) + 1, false)`); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(wishes), nil
+}
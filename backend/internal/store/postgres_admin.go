@@ -0,0 +1,57 @@
+// backend/internal/store/postgres_admin.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"wedding-backend/internal/models"
+)
+
+func (p *Postgres) ListAdmins(ctx context.Context) ([]models.Admin, error) {
+	rows, err := p.db.QueryContext(ctx, "SELECT chat_id, role, name, created_at FROM admins ORDER BY created_at, chat_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var admins []models.Admin
+	for rows.Next() {
+		var a models.Admin
+		if err := rows.Scan(&a.ChatID, &a.Role, &a.Name, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		admins = append(admins, a)
+	}
+	return admins, rows.Err()
+}
+
+func (p *Postgres) GetAdmin(ctx context.Context, chatID int64) (models.Admin, error) {
+	var a models.Admin
+	err := p.db.QueryRowContext(ctx,
+		"SELECT chat_id, role, name, created_at FROM admins WHERE chat_id = $1", chatID,
+	).Scan(&a.ChatID, &a.Role, &a.Name, &a.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Admin{}, ErrNotFound
+	}
+	return a, err
+}
+
+func (p *Postgres) SaveAdmin(ctx context.Context, admin *models.Admin) error {
+	return p.db.QueryRowContext(ctx, `
+		INSERT INTO admins (chat_id, role, name) VALUES ($1, $2, $3)
+		ON CONFLICT (chat_id) DO UPDATE SET role = EXCLUDED.role, name = EXCLUDED.name
+		RETURNING created_at`,
+		admin.ChatID, admin.Role, admin.Name,
+	).Scan(&admin.CreatedAt)
+}
+
+func (p *Postgres) DeleteAdmin(ctx context.Context, chatID int64) (bool, error) {
+	res, err := p.db.ExecContext(ctx, "DELETE FROM admins WHERE chat_id = $1", chatID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,67 @@
+// backend/internal/store/postgres_audit.go
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"wedding-backend/internal/models"
+)
+
+func (p *Postgres) AddAudit(ctx context.Context, event *models.AuditEvent) error {
+	ids := make(pq.Int64Array, len(event.WishIDs))
+	for i, id := range event.WishIDs {
+		ids[i] = int64(id)
+	}
+	return p.db.QueryRowContext(ctx,
+		"INSERT INTO audit_events (actor_id, action, wish_ids, before, after) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at",
+		event.ActorID, event.Action, ids, nullJSON(event.Before), nullJSON(event.After),
+	).Scan(&event.ID, &event.CreatedAt)
+}
+
+func (p *Postgres) ListAudit(ctx context.Context, filter AuditFilter) ([]models.AuditEvent, error) {
+	query := "SELECT id, actor_id, action, wish_ids, before, after, created_at FROM audit_events"
+	var args []any
+	if filter.WishID > 0 {
+		args = append(args, filter.WishID)
+		query += fmt.Sprintf(" WHERE $%d = ANY(wish_ids)", len(args))
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []models.AuditEvent
+	for rows.Next() {
+		var e models.AuditEvent
+		var ids pq.Int64Array
+		var before, after []byte
+		if err := rows.Scan(&e.ID, &e.ActorID, &e.Action, &ids, &before, &after, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.WishIDs = make([]int, len(ids))
+		for i, id := range ids {
+			e.WishIDs[i] = int(id)
+		}
+		e.Before, e.After = before, after
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// nullJSON — пустой снимок сохраняем как NULL, а не как невалидный JSONB
+func nullJSON(data []byte) any {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
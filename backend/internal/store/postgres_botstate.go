@@ -0,0 +1,79 @@
+// backend/internal/store/postgres_botstate.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// backupLockID — ключ advisory-блокировки резервного копирования, чтобы копию по расписанию
+// снимал один экземпляр
+const backupLockID = 0x6261636b // "back"
+
+// Ключи в bot_state
+const (
+	telegramOffsetKey = "telegram_offset"
+	lastBackupKey     = "last_backup"
+)
+
+func (p *Postgres) TelegramOffset(ctx context.Context) (int, error) {
+	var value string
+	err := p.db.QueryRowContext(ctx, "SELECT value FROM bot_state WHERE key = $1", telegramOffsetKey).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(value)
+}
+
+func (p *Postgres) SaveTelegramOffset(ctx context.Context, offset int) error {
+	return p.saveBotState(ctx, telegramOffsetKey, strconv.Itoa(offset))
+}
+
+func (p *Postgres) LastBackupAt(ctx context.Context) (time.Time, error) {
+	var value string
+	err := p.db.QueryRowContext(ctx, "SELECT value FROM bot_state WHERE key = $1", lastBackupKey).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+func (p *Postgres) SaveLastBackupAt(ctx context.Context, at time.Time) error {
+	return p.saveBotState(ctx, lastBackupKey, at.UTC().Format(time.RFC3339Nano))
+}
+
+// WithBackupLock держит advisory-блокировку на отдельном соединении, пока выполняется fn
+func (p *Postgres) WithBackupLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	conn, err := p.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", backupLockID).Scan(&locked); err != nil {
+		return false, err
+	}
+	if !locked {
+		return false, nil
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", backupLockID)
+	return true, fn(ctx)
+}
+
+func (p *Postgres) saveBotState(ctx context.Context, key, value string) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO bot_state (key, value) VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()`,
+		key, value)
+	return err
+}
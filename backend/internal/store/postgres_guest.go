@@ -0,0 +1,88 @@
+// backend/internal/store/postgres_guest.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"wedding-backend/internal/models"
+)
+
+// guestColumns — столбцы, которые читает scanGuest, в том же порядке
+const guestColumns = "id, token, name, party_size, events, created_at"
+
+func scanGuest(row rowScanner) (models.Guest, error) {
+	var g models.Guest
+	err := row.Scan(&g.ID, &g.Token, &g.Name, &g.PartySize, pq.Array(&g.Events), &g.CreatedAt)
+	return g, err
+}
+
+func (p *Postgres) ListGuests(ctx context.Context) ([]models.Guest, error) {
+	rows, err := p.db.QueryContext(ctx, "SELECT "+guestColumns+" FROM guests ORDER BY name, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var guests []models.Guest
+	for rows.Next() {
+		g, err := scanGuest(rows)
+		if err != nil {
+			return nil, err
+		}
+		guests = append(guests, g)
+	}
+	return guests, rows.Err()
+}
+
+func (p *Postgres) GetGuest(ctx context.Context, id int) (models.Guest, error) {
+	g, err := scanGuest(p.db.QueryRowContext(ctx, "SELECT "+guestColumns+" FROM guests WHERE id = $1", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return g, ErrNotFound
+	}
+	return g, err
+}
+
+func (p *Postgres) GetGuestByToken(ctx context.Context, token string) (models.Guest, error) {
+	g, err := scanGuest(p.db.QueryRowContext(ctx, "SELECT "+guestColumns+" FROM guests WHERE token = $1", token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return g, ErrNotFound
+	}
+	return g, err
+}
+
+func (p *Postgres) CreateGuest(ctx context.Context, guest *models.Guest) error {
+	return p.db.QueryRowContext(ctx,
+		"INSERT INTO guests (token, name, party_size, events) VALUES ($1, $2, $3, $4) RETURNING id, created_at",
+		guest.Token, guest.Name, guest.PartySize, pq.Array(guest.Events),
+	).Scan(&guest.ID, &guest.CreatedAt)
+}
+
+func (p *Postgres) SaveGuests(ctx context.Context, guests []models.Guest) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i := range guests {
+		g := &guests[i]
+		if g.ID == 0 {
+			err = tx.QueryRowContext(ctx,
+				"INSERT INTO guests (token, name, party_size, events) VALUES ($1, $2, $3, $4) RETURNING id, created_at",
+				g.Token, g.Name, g.PartySize, pq.Array(g.Events),
+			).Scan(&g.ID, &g.CreatedAt)
+		} else {
+			_, err = tx.ExecContext(ctx,
+				"UPDATE guests SET token = $2, name = $3, party_size = $4, events = $5 WHERE id = $1",
+				g.ID, g.Token, g.Name, g.PartySize, pq.Array(g.Events))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
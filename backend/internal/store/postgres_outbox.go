@@ -0,0 +1,129 @@
+// backend/internal/store/postgres_outbox.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+// outboxColumns — столбцы, которые читает scanOutbox, в том же порядке
+const outboxColumns = "id, channel, payload, status, attempts, next_attempt_at, last_error, created_at"
+
+func scanOutbox(row rowScanner) (models.OutboxEntry, error) {
+	var e models.OutboxEntry
+	var payload []byte
+	err := row.Scan(&e.ID, &e.Channel, &payload, &e.Status, &e.Attempts, &e.NextAttemptAt, &e.LastError, &e.CreatedAt)
+	e.Payload = payload
+	return e, err
+}
+
+// execer — общий интерфейс *sql.DB и *sql.Tx для записи
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func insertOutbox(ctx context.Context, db execer, entries []models.OutboxEntry) error {
+	for _, e := range entries {
+		_, err := db.ExecContext(ctx,
+			"INSERT INTO outbox (channel, payload) VALUES ($1, $2)",
+			e.Channel, []byte(e.Payload))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Postgres) EnqueueOutbox(ctx context.Context, entries []models.OutboxEntry) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertOutbox(ctx, tx, entries); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (p *Postgres) ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEntry, error) {
+	// SKIP LOCKED и сдвиг next_attempt_at не дают двум экземплярам взять одно уведомление
+	rows, err := p.db.QueryContext(ctx, `
+		UPDATE outbox SET next_attempt_at = NOW() + $2 * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+outboxColumns,
+		limit, int(lease/time.Second))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.OutboxEntry
+	for rows.Next() {
+		e, err := scanOutbox(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (p *Postgres) MarkOutboxSent(ctx context.Context, id int) error {
+	_, err := p.db.ExecContext(ctx,
+		"UPDATE outbox SET status = 'sent', attempts = attempts + 1, last_error = '', sent_at = NOW() WHERE id = $1", id)
+	return err
+}
+
+func (p *Postgres) MarkOutboxFailed(ctx context.Context, id int, lastErr string, next time.Time, dead bool) error {
+	status := models.OutboxPending
+	if dead {
+		status = models.OutboxDead
+	}
+	_, err := p.db.ExecContext(ctx,
+		"UPDATE outbox SET status = $2, attempts = attempts + 1, last_error = $3, next_attempt_at = $4 WHERE id = $1",
+		id, status, lastErr, next)
+	return err
+}
+
+func (p *Postgres) ListOutbox(ctx context.Context, status string, limit int) ([]models.OutboxEntry, error) {
+	rows, err := p.db.QueryContext(ctx,
+		"SELECT "+outboxColumns+" FROM outbox WHERE status = $1 ORDER BY id DESC LIMIT $2", status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.OutboxEntry
+	for rows.Next() {
+		e, err := scanOutbox(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (p *Postgres) RetryOutbox(ctx context.Context, id int) error {
+	res, err := p.db.ExecContext(ctx,
+		"UPDATE outbox SET status = 'pending', attempts = 0, next_attempt_at = NOW() WHERE id = $1 AND status = 'dead'", id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err == nil && n == 0 {
+		err = ErrNotFound
+	}
+	return err
+}
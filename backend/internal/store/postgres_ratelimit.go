@@ -0,0 +1,56 @@
+// backend/internal/store/postgres_ratelimit.go
+package store
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TakeTokens пополняет вёдра по времени БД (одинаковому для всех экземпляров) и забирает токены.
+// Запрос блокирует строки до конца транзакции, поэтому параллельные запросы не берут один токен дважды;
+// вёдра блокируются в порядке ключей, чтобы два запроса не ждали друг друга.
+func (p *Postgres) TakeTokens(ctx context.Context, buckets []Bucket) (bool, time.Duration, error) {
+	buckets = slices.Clone(buckets)
+	slices.SortFunc(buckets, func(a, b Bucket) int { return strings.Compare(a.Key, b.Key) })
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, 0, err
+	}
+	defer tx.Rollback()
+
+	var wait time.Duration
+	for _, b := range buckets {
+		var tokens float64
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO rate_limits AS r (key, tokens, updated_at) VALUES ($1, $2, NOW())
+			ON CONFLICT (key) DO UPDATE SET
+				tokens = LEAST($2, r.tokens + EXTRACT(EPOCH FROM NOW() - r.updated_at) * $3),
+				updated_at = NOW()
+			RETURNING tokens`,
+			b.Key, float64(b.Burst), b.Rate).Scan(&tokens)
+		if err != nil {
+			return false, 0, err
+		}
+		if tokens < 1 {
+			wait = max(wait, tokenWait(tokens, b.Rate))
+		}
+	}
+	if wait > 0 {
+		return false, wait, tx.Commit()
+	}
+
+	for _, b := range buckets {
+		if _, err := tx.ExecContext(ctx, "UPDATE rate_limits SET tokens = tokens - 1 WHERE key = $1", b.Key); err != nil {
+			return false, 0, err
+		}
+	}
+	return true, 0, tx.Commit()
+}
+
+func (p *Postgres) PruneTokens(ctx context.Context, before time.Time) error {
+	_, err := p.db.ExecContext(ctx, "DELETE FROM rate_limits WHERE updated_at < $1", before)
+	return err
+}
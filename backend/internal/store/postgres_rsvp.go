@@ -0,0 +1,77 @@
+// backend/internal/store/postgres_rsvp.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"wedding-backend/internal/models"
+)
+
+// rsvpColumns — столбцы, которые читает scanRSVP, в том же порядке
+const rsvpColumns = "id, token, name, attending, plus_ones, dietary, needs_transfer, guest_id, created_at, updated_at"
+
+func scanRSVP(row rowScanner) (models.RSVP, error) {
+	var r models.RSVP
+	var guestID sql.NullInt64
+	err := row.Scan(&r.ID, &r.Token, &r.Name, &r.Attending, &r.PlusOnes, &r.Dietary, &r.NeedsTransfer, &guestID, &r.CreatedAt, &r.UpdatedAt)
+	r.GuestID = intPtr(guestID)
+	return r, err
+}
+
+func (p *Postgres) ListRSVPs(ctx context.Context) ([]models.RSVP, error) {
+	rows, err := p.db.QueryContext(ctx, "SELECT "+rsvpColumns+" FROM rsvps ORDER BY created_at DESC, id DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rsvps []models.RSVP
+	for rows.Next() {
+		r, err := scanRSVP(rows)
+		if err != nil {
+			return nil, err
+		}
+		rsvps = append(rsvps, r)
+	}
+	return rsvps, rows.Err()
+}
+
+func (p *Postgres) GetRSVP(ctx context.Context, token string) (models.RSVP, error) {
+	r, err := scanRSVP(p.db.QueryRowContext(ctx, "SELECT "+rsvpColumns+" FROM rsvps WHERE token = $1", token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return r, ErrNotFound
+	}
+	return r, err
+}
+
+func (p *Postgres) GetRSVPByGuest(ctx context.Context, guestID int) (models.RSVP, error) {
+	r, err := scanRSVP(p.db.QueryRowContext(ctx, "SELECT "+rsvpColumns+" FROM rsvps WHERE guest_id = $1", guestID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return r, ErrNotFound
+	}
+	return r, err
+}
+
+func (p *Postgres) CreateRSVP(ctx context.Context, rsvp *models.RSVP) error {
+	return p.db.QueryRowContext(ctx,
+		`INSERT INTO rsvps (token, name, attending, plus_ones, dietary, needs_transfer, guest_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at, updated_at`,
+		rsvp.Token, rsvp.Name, rsvp.Attending, rsvp.PlusOnes, rsvp.Dietary, rsvp.NeedsTransfer, rsvp.GuestID,
+	).Scan(&rsvp.ID, &rsvp.CreatedAt, &rsvp.UpdatedAt)
+}
+
+func (p *Postgres) UpdateRSVP(ctx context.Context, rsvp *models.RSVP) error {
+	var guestID sql.NullInt64
+	err := p.db.QueryRowContext(ctx,
+		`UPDATE rsvps SET name = $2, attending = $3, plus_ones = $4, dietary = $5, needs_transfer = $6, updated_at = NOW()
+		WHERE token = $1 RETURNING id, guest_id, created_at, updated_at`,
+		rsvp.Token, rsvp.Name, rsvp.Attending, rsvp.PlusOnes, rsvp.Dietary, rsvp.NeedsTransfer,
+	).Scan(&rsvp.ID, &guestID, &rsvp.CreatedAt, &rsvp.UpdatedAt)
+	rsvp.GuestID = intPtr(guestID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
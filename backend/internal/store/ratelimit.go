@@ -0,0 +1,29 @@
+// backend/internal/store/ratelimit.go
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// Bucket — ведро токенов ёмкостью Burst, которое пополняется со скоростью Rate токенов в секунду
+type Bucket struct {
+	Key   string
+	Burst int
+	Rate  float64
+}
+
+// RateLimitStore — вёдра токенов для ограничения частоты запросов
+type RateLimitStore interface {
+	// TakeTokens забирает по токену из каждого ведра, только если токен есть во всех:
+	// запрос, отклонённый одним лимитом, не расходует другие. Иначе возвращает false
+	// и паузу, после которой токены будут во всех вёдрах.
+	TakeTokens(ctx context.Context, buckets []Bucket) (bool, time.Duration, error)
+	// PruneTokens удаляет вёдра, которые не трогали с before (к этому времени они снова полные)
+	PruneTokens(ctx context.Context, before time.Time) error
+}
+
+// tokenWait — сколько ждать, пока в ведре с tokens < 1 накопится целый токен
+func tokenWait(tokens, rate float64) time.Duration {
+	return time.Duration((1 - tokens) / rate * float64(time.Second))
+}
@@ -0,0 +1,23 @@
+// backend/internal/store/rsvp.go
+package store
+
+import (
+	"context"
+
+	"wedding-backend/internal/models"
+)
+
+// RSVPStore — хранилище ответов гостей на приглашение
+type RSVPStore interface {
+	// ListRSVPs возвращает все ответы, новые первыми
+	ListRSVPs(ctx context.Context) ([]models.RSVP, error)
+	// GetRSVP возвращает ответ по токену или ErrNotFound
+	GetRSVP(ctx context.Context, token string) (models.RSVP, error)
+	// GetRSVPByGuest возвращает ответ приглашённого гостя или ErrNotFound
+	GetRSVPByGuest(ctx context.Context, guestID int) (models.RSVP, error)
+	// CreateRSVP сохраняет ответ (Token задаёт вызывающий) и заполняет ID и даты
+	CreateRSVP(ctx context.Context, rsvp *models.RSVP) error
+	// UpdateRSVP перезаписывает ответ с тем же Token (кроме GuestID),
+	// возвращает ErrNotFound, если его нет
+	UpdateRSVP(ctx context.Context, rsvp *models.RSVP) error
+}
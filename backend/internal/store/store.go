@@ -0,0 +1,63 @@
+// backend/internal/store/store.go
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wedding-backend/internal/models"
+)
+
+// ErrNotFound — пожелание с таким ID не найдено
+var ErrNotFound = errors.New("wish not found")
+
+// Store — все хранилища приложения; Postgres и Memory реализуют его целиком
+type Store interface {
+	WishStore
+	RSVPStore
+	GuestStore
+	OutboxStore
+	BotStateStore
+	AdminStore
+	AuditStore
+	RateLimitStore
+}
+
+// WishStore — хранилище пожеланий, через которое работают все обработчики
+type WishStore interface {
+	// List возвращает пожелания по фильтру, новые первыми
+	List(ctx context.Context, filter ListFilter) ([]models.Wish, error)
+	// Get возвращает пожелание по ID или ErrNotFound (в том числе если оно в корзине)
+	Get(ctx context.Context, id int) (models.Wish, error)
+	// Create сохраняет пожелание и заполняет ID и CreatedAt;
+	// пустой Status сохраняется как одобренный. Уведомления от outbox (может быть nil)
+	// ставятся в очередь в той же транзакции.
+	Create(ctx context.Context, wish *models.Wish, outbox OutboxFunc) error
+	// SetStatus меняет статус модерации, возвращает ErrNotFound, если пожелания нет
+	SetStatus(ctx context.Context, id int, status string) error
+	// Delete переносит пожелание в корзину, возвращает false, если его не было
+	Delete(ctx context.Context, id int) (bool, error)
+	// DeleteAll переносит в корзину все пожелания и возвращает их количество
+	// и общее время удаления — по нему UndeleteAll отменит именно эту операцию
+	DeleteAll(ctx context.Context) (int64, time.Time, error)
+	// Undelete возвращает пожелание из корзины, ErrNotFound — если его там нет
+	Undelete(ctx context.Context, id int) (models.Wish, error)
+	// UndeleteAll возвращает из корзины пожелания, удалённые в момент at
+	UndeleteAll(ctx context.Context, at time.Time) (int64, error)
+	// Purge окончательно удаляет пожелания, перенесённые в корзину раньше before, и возвращает их ID
+	Purge(ctx context.Context, before time.Time) ([]int, error)
+	// Restore в одной транзакции окончательно удаляет пожелания с ID из remove (включая корзину)
+	// и вставляет или обновляет wishes по ID вместе с DeletedAt. Удаляются только перечисленные ID:
+	// пожелание, добавленное после предпросмотра, замена не тронет. Пустой Status сохраняется как одобренный.
+	// После восстановления новые ID продолжаются с максимального.
+	Restore(ctx context.Context, wishes []models.Wish, remove []int) (int, error)
+}
+
+// statusOrDefault подставляет статус по умолчанию для старых записей и бэкапов
+func statusOrDefault(s string) string {
+	if s == "" {
+		return models.StatusApproved
+	}
+	return s
+}
@@ -2,46 +2,170 @@
 package telegram
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"time"
 )
 
-// Send отправляет сообщение в Telegram
-func Send(message string) {
-	token := os.Getenv("TG_TOKEN")
-	chatID := os.Getenv("CHAT_ID")
-
-	// Проверка, что переменные окружения заданы
-	if token == "" || chatID == "" {
-		return // Не отправляем, если нет данных
-	}
-
-	// Формируем URL
-	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", token)
-	data := url.Values{}
-	data.Set("chat_id", chatID)
-	data.Set("text", message)
-	data.Set("parse_mode", "HTML") // Поддержка <b>, <i>
-
-	// Отправляем POST-запрос
-	resp, err := http.Post(
-		apiURL,
-		"application/x-www-form-urlencoded",
-		strings.NewReader(data.Encode()),
-	)
+// DefaultBaseURL — адрес Bot API; в тестах его заменяют адресом локального фейкового сервера
+const DefaultBaseURL = "https://api.telegram.org"
+
+// Client — клиент Telegram Bot API
+type Client struct {
+	Token   string
+	BaseURL string
+	HTTP    *http.Client
+}
+
+// NewClient создаёт клиент с адресом Bot API по умолчанию.
+// Таймаут HTTP больше таймаута long polling в getUpdates.
+func NewClient(token string) *Client {
+	return &Client{
+		Token:   token,
+		BaseURL: DefaultBaseURL,
+		HTTP:    &http.Client{Timeout: 90 * time.Second},
+	}
+}
+
+// FromEnv создаёт клиент из TG_TOKEN; TELEGRAM_API_URL переопределяет адрес Bot API
+// (например, локальный telegram-bot-api или фейковый сервер)
+func FromEnv() *Client {
+	c := NewClient(os.Getenv("TG_TOKEN"))
+	if base := os.Getenv("TELEGRAM_API_URL"); base != "" {
+		c.BaseURL = base
+	}
+	return c
+}
+
+// Configured сообщает, задан ли токен бота
+func (c *Client) Configured() bool {
+	return c != nil && c.Token != ""
+}
+
+// APIError — ошибка Bot API. RetryAfter (в секундах) Telegram задаёт при 429 Too Many Requests.
+type APIError struct {
+	Code        int
+	Description string
+	RetryAfter  int
+}
+
+func (e *APIError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("Telegram API %d: %s (повтор через %d с)", e.Code, e.Description, e.RetryAfter)
+	}
+	return fmt.Sprintf("Telegram API %d: %s", e.Code, e.Description)
+}
+
+// ErrNoToken — клиент создан без TG_TOKEN
+var ErrNoToken = errors.New("TG_TOKEN не задан")
+
+// apiResponse — общий конверт ответа Bot API
+type apiResponse struct {
+	Ok          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"`
+	Description string          `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTP != nil {
+		return c.HTTP
+	}
+	return http.DefaultClient
+}
+
+func (c *Client) baseURL() string {
+	if c.BaseURL != "" {
+		return strings.TrimRight(c.BaseURL, "/")
+	}
+	return DefaultBaseURL
+}
+
+// call вызывает метод Bot API с параметрами формы и разбирает result в out (может быть nil)
+func (c *Client) call(ctx context.Context, method string, params url.Values, out any) error {
+	return c.do(ctx, method, "application/x-www-form-urlencoded", strings.NewReader(params.Encode()), out)
+}
+
+// callMultipart вызывает метод Bot API с загрузкой файла в поле fileField
+func (c *Client) callMultipart(ctx context.Context, method string, params url.Values, fileField, fileName string, data []byte, out any) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for key, values := range params {
+		for _, v := range values {
+			if err := writer.WriteField(key, v); err != nil {
+				return err
+			}
+		}
+	}
+	part, err := writer.CreateFormFile(fileField, fileName)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(data); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return c.do(ctx, method, writer.FormDataContentType(), &body, out)
+}
+
+func (c *Client) do(ctx context.Context, method, contentType string, body io.Reader, out any) error {
+	if c.Token == "" {
+		return ErrNoToken
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL()+"/bot"+c.Token+"/"+method, body)
 	if err != nil {
-		fmt.Printf("❌ Ошибка отправки в Telegram: %v\n", err)
-		return
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		// В тексте ошибки net/http есть URL с токеном — не пускаем его в логи
+		return fmt.Errorf("%s: %w", method, redact(err, c.Token))
 	}
 	defer resp.Body.Close()
 
-	// Проверяем статус
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		fmt.Printf("❌ Telegram API вернул ошибку: %s\n", body)
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var result apiResponse
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return &APIError{Code: resp.StatusCode, Description: strings.TrimSpace(string(raw))}
+	}
+	if !result.Ok {
+		code := result.ErrorCode
+		if code == 0 {
+			code = resp.StatusCode
+		}
+		return &APIError{Code: code, Description: result.Description, RetryAfter: result.Parameters.RetryAfter}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(result.Result, out)
+}
+
+// redact убирает токен бота из текста ошибки
+func redact(err error, token string) error {
+	if token == "" || !strings.Contains(err.Error(), token) {
+		return err
 	}
+	return fmt.Errorf("%s", strings.ReplaceAll(err.Error(), token, "<token>"))
 }
@@ -0,0 +1,178 @@
+// backend/internal/telegram/methods.go
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// keyboard кодирует inline-клавиатуру для reply_markup; без кнопок — пустая строка
+func keyboard(buttons [][]InlineButton) (string, error) {
+	if len(buttons) == 0 {
+		return "", nil
+	}
+	markup, err := json.Marshal(map[string][][]InlineButton{"inline_keyboard": buttons})
+	return string(markup), err
+}
+
+// SendMessage отправляет сообщение в HTML-разметке (<b>, <i>, <code>) с inline-клавиатурой (может быть nil)
+func (c *Client) SendMessage(ctx context.Context, chatID int64, text string, buttons [][]InlineButton) (Message, error) {
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("text", text)
+	params.Set("parse_mode", "HTML")
+	markup, err := keyboard(buttons)
+	if err != nil {
+		return Message{}, err
+	}
+	if markup != "" {
+		params.Set("reply_markup", markup)
+	}
+
+	var msg Message
+	err = c.call(ctx, "sendMessage", params, &msg)
+	return msg, err
+}
+
+// EditMessageText заменяет текст отправленного сообщения; buttons == nil убирает клавиатуру
+func (c *Client) EditMessageText(ctx context.Context, chatID int64, messageID int, text string, buttons [][]InlineButton) error {
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("message_id", strconv.Itoa(messageID))
+	params.Set("text", text)
+	params.Set("parse_mode", "HTML")
+	markup, err := keyboard(buttons)
+	if err != nil {
+		return err
+	}
+	if markup != "" {
+		params.Set("reply_markup", markup)
+	}
+	return c.call(ctx, "editMessageText", params, nil)
+}
+
+// SendDocument отправляет файл с подписью (может быть пустой)
+func (c *Client) SendDocument(ctx context.Context, chatID int64, fileName string, data []byte, caption string) (Message, error) {
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	if caption != "" {
+		params.Set("caption", caption)
+		params.Set("parse_mode", "HTML")
+	}
+
+	var msg Message
+	err := c.callMultipart(ctx, "sendDocument", params, "document", fileName, data, &msg)
+	return msg, err
+}
+
+// GetFile возвращает путь к файлу для DownloadFile
+func (c *Client) GetFile(ctx context.Context, fileID string) (File, error) {
+	params := url.Values{}
+	params.Set("file_id", fileID)
+
+	var file File
+	err := c.call(ctx, "getFile", params, &file)
+	return file, err
+}
+
+// DownloadFile скачивает файл по file_path из GetFile, не больше limit байт
+func (c *Client) DownloadFile(ctx context.Context, filePath string, limit int64) ([]byte, error) {
+	if c.Token == "" {
+		return nil, ErrNoToken
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL()+"/file/bot"+c.Token+"/"+filePath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, redact(err, c.Token)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{Code: resp.StatusCode, Description: "не удалось скачать файл"}
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("файл больше %d байт", limit)
+	}
+	return data, nil
+}
+
+// AnswerCallbackQuery отвечает на нажатие inline-кнопки всплывающим текстом
+func (c *Client) AnswerCallbackQuery(ctx context.Context, callbackID, text string) error {
+	params := url.Values{}
+	params.Set("callback_query_id", callbackID)
+	params.Set("text", text)
+	return c.call(ctx, "answerCallbackQuery", params, nil)
+}
+
+// SetWebhook регистрирует адрес, на который Telegram будет присылать обновления
+func (c *Client) SetWebhook(ctx context.Context, opts WebhookOptions) error {
+	params := url.Values{}
+	params.Set("url", opts.URL)
+	if opts.SecretToken != "" {
+		params.Set("secret_token", opts.SecretToken)
+	}
+	if opts.AllowedUpdates != nil {
+		allowed, err := json.Marshal(opts.AllowedUpdates)
+		if err != nil {
+			return err
+		}
+		params.Set("allowed_updates", string(allowed))
+	}
+	if opts.DropPending {
+		params.Set("drop_pending_updates", "true")
+	}
+	return c.call(ctx, "setWebhook", params, nil)
+}
+
+// GetUpdates забирает обновления начиная с offset; timeout — секунды long polling
+func (c *Client) GetUpdates(ctx context.Context, offset, timeout int) ([]Update, error) {
+	params := url.Values{}
+	if offset > 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
+	params.Set("timeout", strconv.Itoa(timeout))
+
+	var updates []Update
+	err := c.call(ctx, "getUpdates", params, &updates)
+	return updates, err
+}
+
+// DeleteWebhook отключает вебхук (нужно для getUpdates); dropPending отбрасывает накопившиеся обновления
+func (c *Client) DeleteWebhook(ctx context.Context, dropPending bool) error {
+	params := url.Values{}
+	if dropPending {
+		params.Set("drop_pending_updates", "true")
+	}
+	return c.call(ctx, "deleteWebhook", params, nil)
+}
+
+// GetMe возвращает самого бота; Username нужен, чтобы разбирать команды вида /list@bot
+func (c *Client) GetMe(ctx context.Context) (User, error) {
+	var me User
+	err := c.call(ctx, "getMe", url.Values{}, &me)
+	return me, err
+}
+
+// SetMyCommands задаёт меню команд, которое Telegram показывает в чате с ботом
+func (c *Client) SetMyCommands(ctx context.Context, commands []BotCommand) error {
+	list, err := json.Marshal(commands)
+	if err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("commands", string(list))
+	return c.call(ctx, "setMyCommands", params, nil)
+}
@@ -0,0 +1,85 @@
+// backend/internal/telegram/poller.go
+package telegram
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+// OffsetStore хранит offset getUpdates между перезапусками
+type OffsetStore interface {
+	TelegramOffset(ctx context.Context) (int, error)
+	SaveTelegramOffset(ctx context.Context, offset int) error
+}
+
+// Poller получает обновления через getUpdates — для локального запуска без публичного HTTPS-адреса
+type Poller struct {
+	Bot     *Client
+	Offsets OffsetStore
+	// Timeout — длительность long polling в секундах
+	Timeout int
+}
+
+// Run снимает вебхук (иначе getUpdates вернёт 409) и передаёт обновления handle по одному,
+// пока не отменён ctx. Offset сохраняется после каждого обновления.
+func (p *Poller) Run(ctx context.Context, handle func(ctx context.Context, update Update)) error {
+	if err := p.Bot.DeleteWebhook(ctx, false); err != nil {
+		return err
+	}
+
+	offset, err := p.Offsets.TelegramOffset(ctx)
+	if err != nil {
+		return err
+	}
+
+	failures := 0
+	for ctx.Err() == nil {
+		updates, err := p.Bot.GetUpdates(ctx, offset, p.Timeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			failures++
+			delay := pollRetryDelay(failures, err)
+			log.Printf("⚠️ Ошибка getUpdates, повтор через %s: %v", delay, err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(delay):
+			}
+			continue
+		}
+		failures = 0
+
+		for _, update := range updates {
+			handle(ctx, update)
+
+			offset = update.UpdateID + 1
+			if err := p.Offsets.SaveTelegramOffset(ctx, offset); err != nil {
+				log.Printf("❌ Ошибка сохранения offset: %v", err)
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+// pollRetryDelay — пауза после неудачного getUpdates: 1 с, 2 с, 4 с… до минуты;
+// 409 значит, что бот опрашивает кто-то ещё или снова установлен вебхук
+func pollRetryDelay(failures int, err error) time.Duration {
+	delay := time.Minute
+	if failures < 7 {
+		delay = time.Second << (failures - 1)
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.RetryAfter > 0 {
+			delay = time.Duration(apiErr.RetryAfter) * time.Second
+		} else if apiErr.Code == http.StatusConflict {
+			delay = time.Minute
+		}
+	}
+	return delay
+}
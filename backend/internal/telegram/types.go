@@ -0,0 +1,72 @@
+// backend/internal/telegram/types.go
+package telegram
+
+// Update — обновление от Telegram (вебхук или getUpdates)
+type Update struct {
+	UpdateID      int            `json:"update_id"`
+	Message       *Message       `json:"message,omitempty"`
+	CallbackQuery *CallbackQuery `json:"callback_query,omitempty"`
+}
+
+// Message — сообщение в чате
+type Message struct {
+	MessageID int       `json:"message_id"`
+	From      *User     `json:"from,omitempty"`
+	Chat      Chat      `json:"chat"`
+	Text      string    `json:"text"`
+	Document  *Document `json:"document,omitempty"`
+}
+
+// User — пользователь Telegram; в личном чате его ID совпадает с ID чата
+type User struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+	Username  string `json:"username,omitempty"`
+}
+
+// Chat — чат, из которого пришло сообщение
+type Chat struct {
+	ID int64 `json:"id"`
+}
+
+// Document — файл, присланный боту
+type Document struct {
+	FileID   string `json:"file_id"`
+	FileName string `json:"file_name"`
+	FileSize int64  `json:"file_size"`
+}
+
+// CallbackQuery — нажатие на inline-кнопку
+type CallbackQuery struct {
+	ID      string   `json:"id"`
+	From    User     `json:"from"`
+	Data    string   `json:"data"`
+	Message *Message `json:"message,omitempty"`
+}
+
+// File — ответ getFile; FilePath нужен для скачивания
+type File struct {
+	FileID   string `json:"file_id"`
+	FileSize int64  `json:"file_size"`
+	FilePath string `json:"file_path"`
+}
+
+// InlineButton — кнопка inline-клавиатуры; Data вернётся в callback_query
+type InlineButton struct {
+	Text string `json:"text"`
+	Data string `json:"callback_data"`
+}
+
+// WebhookOptions — параметры setWebhook
+type WebhookOptions struct {
+	URL            string
+	SecretToken    string
+	AllowedUpdates []string
+	DropPending    bool
+}
+
+// BotCommand — пункт меню команд бота (setMyCommands)
+type BotCommand struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
@@ -2,13 +2,32 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
+	_ "time/tzdata" // часовые пояса без системной tzdata
 
+	"wedding-backend/internal/admins"
+	"wedding-backend/internal/backup"
+	"wedding-backend/internal/challenge"
 	"wedding-backend/internal/database"
+	"wedding-backend/internal/events"
 	"wedding-backend/internal/handlers"
+	"wedding-backend/internal/models"
+	"wedding-backend/internal/notify"
+	"wedding-backend/internal/outbox"
+	"wedding-backend/internal/ratelimit"
+	"wedding-backend/internal/spam"
+	"wedding-backend/internal/store"
+	"wedding-backend/internal/telegram"
 )
 
 // loadEnv загружает переменные из .env, если файл существует (для локальной разработки)
@@ -53,6 +72,14 @@ func getPort() string {
 	return "8080"
 }
 
+// getEnv возвращает переменную окружения или значение по умолчанию
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
 // withCORS добавляет заголовки CORS
 func withCORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -66,9 +93,10 @@ func withCORS(next http.Handler) http.Handler {
 		}
 
 		w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.Header().Set("Access-Control-Expose-Headers", "Retry-After")
 
 		// Обработка preflight-запросов
 		if r.Method == "OPTIONS" {
@@ -80,10 +108,164 @@ func withCORS(next http.Handler) http.Handler {
 	})
 }
 
+// runMigrate обрабатывает подкоманду: app migrate status|up|down [N]
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		log.Fatal("Использование: app migrate status|up|down [N]")
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "status":
+		statuses, err := database.Status(ctx, database.DB)
+		for _, s := range statuses {
+			applied := "не применена"
+			if s.AppliedAt != nil {
+				applied = "применена " + s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", s.Version, s.Name, applied)
+		}
+		if err != nil {
+			log.Fatal("❌ ", err)
+		}
+
+	case "up":
+		n, err := database.MigrateUp(ctx, database.DB)
+		if err != nil {
+			log.Fatal("❌ Ошибка миграции: ", err)
+		}
+		log.Printf("✅ Применено миграций: %d", n)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				log.Fatal("❌ Количество шагов должно быть положительным числом")
+			}
+		}
+		n, err := database.MigrateDown(ctx, database.DB, steps)
+		if err != nil {
+			log.Fatal("❌ Ошибка отката: ", err)
+		}
+		log.Printf("✅ Откачено миграций: %d", n)
+
+	default:
+		log.Fatalf("Неизвестная подкоманда migrate %q, используйте status|up|down", args[0])
+	}
+}
+
+// secretTokenRegex — допустимый секрет вебхука по правилам Bot API
+var secretTokenRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+// webhookPath — путь вебхука. TELEGRAM_WEBHOOK_PATH=random выводит его из токена и секрета:
+// адрес нельзя угадать, но он не меняется между перезапусками и экземплярами
+func webhookPath() string {
+	p := getEnv("TELEGRAM_WEBHOOK_PATH", "/telegram")
+	if p != "random" {
+		return "/" + strings.TrimLeft(p, "/")
+	}
+	sum := sha256.Sum256([]byte("webhook:" + os.Getenv("TG_TOKEN") + ":" + os.Getenv("TELEGRAM_WEBHOOK_SECRET")))
+	return "/telegram/" + hex.EncodeToString(sum[:16])
+}
+
+// webhookURL — полный адрес вебхука; PUBLIC_URL по умолчанию берётся из RENDER_EXTERNAL_URL
+func webhookURL() string {
+	base := getEnv("PUBLIC_URL", os.Getenv("RENDER_EXTERNAL_URL"))
+	if base == "" {
+		return ""
+	}
+	return strings.TrimRight(base, "/") + webhookPath()
+}
+
+// registerWebhook сообщает Telegram адрес вебхука и секрет для заголовка
+func registerWebhook(ctx context.Context, bot *telegram.Client, webhook string) error {
+	return bot.SetWebhook(ctx, telegram.WebhookOptions{
+		URL:            webhook,
+		SecretToken:    os.Getenv("TELEGRAM_WEBHOOK_SECRET"),
+		AllowedUpdates: []string{"message", "callback_query"},
+	})
+}
+
+// purgeTrash раз в час окончательно удаляет пожелания, пролежавшие в корзине дольше retention,
+// и записывает это в журнал аудита от имени сервера
+func purgeTrash(ctx context.Context, s store.Store, retention time.Duration) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		ids, err := s.Purge(ctx, time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("❌ Ошибка очистки корзины: %v", err)
+		} else if len(ids) > 0 {
+			log.Printf("🧹 Из корзины удалено навсегда: %d", len(ids))
+			if err := s.AddAudit(ctx, &models.AuditEvent{Action: models.AuditPurge, WishIDs: ids}); err != nil {
+				log.Printf("❌ Ошибка записи в журнал аудита: %v", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runWebhook обрабатывает подкоманду: app webhook set|delete [--drop]
+func runWebhook(args []string) {
+	if len(args) == 0 {
+		log.Fatal("Использование: app webhook set|delete [--drop]")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	bot := telegram.FromEnv()
+	if !bot.Configured() {
+		log.Fatal("❌ TG_TOKEN не задан")
+	}
+
+	switch args[0] {
+	case "set":
+		webhook := webhookURL()
+		if webhook == "" {
+			log.Fatal("❌ Задайте PUBLIC_URL — внешний адрес бэкенда")
+		}
+		if err := registerWebhook(ctx, bot, webhook); err != nil {
+			log.Fatal("❌ Ошибка setWebhook: ", err)
+		}
+		log.Printf("✅ Вебхук установлен: %s", webhook)
+
+	case "delete":
+		drop := len(args) > 1 && args[1] == "--drop"
+		if err := bot.DeleteWebhook(ctx, drop); err != nil {
+			log.Fatal("❌ Ошибка deleteWebhook: ", err)
+		}
+		log.Println("✅ Вебхук удалён")
+
+	default:
+		log.Fatalf("Неизвестная подкоманда webhook %q, используйте set|delete", args[0])
+	}
+}
+
 func main() {
 	// Загружаем .env только если он есть (локальная разработка)
 	loadEnv()
 
+	// Секрет вебхука попадёт в заголовок, поэтому проверяем формат заранее
+	webhookSecret := os.Getenv("TELEGRAM_WEBHOOK_SECRET")
+	if webhookSecret != "" && !secretTokenRegex.MatchString(webhookSecret) {
+		log.Fatal("❌ TELEGRAM_WEBHOOK_SECRET: от 1 до 256 символов A-Z, a-z, 0-9, _ и -")
+	}
+
+	// app webhook ... — установить или снять вебхук без БД
+	if len(os.Args) > 1 && os.Args[1] == "webhook" {
+		runWebhook(os.Args[2:])
+		return
+	}
+
 	// Проверяем, задан ли DATABASE_URL
 	dbURL := os.Getenv("DATABASE_URL")
 	if dbURL == "" {
@@ -94,14 +276,199 @@ func main() {
 	database.Connect()
 	defer database.Close()
 
-	// Создаём таблицу, если не существует
+	// app migrate ... — управление схемой без запуска сервера
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+
+	// Применяем недостающие миграции
 	database.Migrate()
 
+	// Лента событий для /api/wishes/stream; EVENTS_LISTEN_NOTIFY=true синхронизирует
+	// её между несколькими экземплярами через Postgres LISTEN/NOTIFY
+	feed := events.NewBroadcaster(100)
+	if os.Getenv("EVENTS_LISTEN_NOTIFY") == "true" {
+		if err := events.ListenPostgres(dbURL, database.DB, feed); err != nil {
+			log.Printf("⚠️ LISTEN/NOTIFY недоступен, события только локальные: %v", err)
+		}
+	}
+
+	// Администраторы бота: CHAT_ID — владелец, ADMINS — ещё из окружения, остальные в БД
+	wishStore := store.NewPostgres(database.DB)
+	registry, err := admins.FromEnv(wishStore)
+	if err != nil {
+		log.Fatal("❌ Ошибка настройки администраторов: ", err)
+	}
+
+	// Каналы уведомлений о новых пожеланиях и ответах гостей (NOTIFIERS)
+	notifier, err := notify.FromEnv(registry.ChatIDs)
+	if err != nil {
+		log.Fatal("❌ Ошибка настройки уведомлений: ", err)
+	}
+	if len(notifier) == 0 {
+		log.Println("⚠️ Каналы уведомлений не настроены — уведомления отключены")
+	} else {
+		log.Printf("✅ Каналы уведомлений: %s", notifier.Name())
+	}
+
+	// Уведомления доставляются через таблицу outbox с повторами;
+	// OUTBOX_MAX_ATTEMPTS — после стольких неудач уведомление видно в /failed
+	maxAttempts, _ := strconv.Atoi(os.Getenv("OUTBOX_MAX_ATTEMPTS"))
+	worker := outbox.New(wishStore, notifier, maxAttempts)
+	go worker.Run(context.Background())
+
+	location, err := time.LoadLocation(getEnv("WEDDING_TZ", "Europe/Moscow"))
+	if err != nil {
+		log.Fatal("❌ Неизвестный часовой пояс WEDDING_TZ: ", err)
+	}
+
+	// Корзина очищается от пожеланий старше TRASH_RETENTION_DAYS (по умолчанию 30 дней)
+	retentionDays, err := strconv.Atoi(getEnv("TRASH_RETENTION_DAYS", "30"))
+	if err != nil || retentionDays <= 0 {
+		log.Fatal("❌ TRASH_RETENTION_DAYS должно быть положительным числом дней")
+	}
+	trashRetention := time.Duration(retentionDays) * 24 * time.Hour
+	go purgeTrash(context.Background(), wishStore, trashRetention)
+
+	// Резервные копии: BACKUP_DIR — каталог для архивов, BACKUP_KEEP — сколько хранить
+	backupKeep, _ := strconv.Atoi(os.Getenv("BACKUP_KEEP"))
+	backups := backup.New(wishStore, os.Getenv("BACKUP_DIR"), backupKeep)
+
+	// Проверки новых пожеланий: SPAM_HONEYPOT, SPAM_PROFANITY, SPAM_LINKS, SPAM_DUPLICATES
+	spamChecks, err := spam.FromEnv(wishStore)
+	if err != nil {
+		log.Fatal("❌ Ошибка настройки проверок пожеланий: ", err)
+	}
+	log.Printf("✅ Проверки пожеланий: %s", spamChecks)
+
+	// WISH_CHALLENGE=true — POST /api/wish принимается только с токеном из GET /api/challenge.
+	// CHALLENGE_SECRET — ключ подписи, общий для всех экземпляров; CHALLENGE_MIN_FILL (3s) — быстрее
+	// форму не заполнить; CHALLENGE_TTL (2h) — срок токена; CHALLENGE_POW_BITS (0) — сложность задачи
+	var formChallenge *challenge.Issuer
+	if os.Getenv("WISH_CHALLENGE") == "true" {
+		minFill, err := time.ParseDuration(getEnv("CHALLENGE_MIN_FILL", challenge.DefaultMinFill.String()))
+		if err != nil || minFill < 0 {
+			log.Fatal("❌ CHALLENGE_MIN_FILL должен быть длительностью, например 3s")
+		}
+		ttl, err := time.ParseDuration(getEnv("CHALLENGE_TTL", challenge.DefaultTTL.String()))
+		if err != nil || ttl <= minFill {
+			log.Fatal("❌ CHALLENGE_TTL должен быть длительностью больше CHALLENGE_MIN_FILL, например 2h")
+		}
+		powBits, err := strconv.Atoi(getEnv("CHALLENGE_POW_BITS", "0"))
+		if err != nil || powBits < 0 || powBits > challenge.MaxDifficulty {
+			log.Fatalf("❌ CHALLENGE_POW_BITS должно быть числом от 0 до %d", challenge.MaxDifficulty)
+		}
+		secret := os.Getenv("CHALLENGE_SECRET")
+		if secret == "" {
+			log.Println("⚠️ CHALLENGE_SECRET не задан — токены формы действуют только до перезапуска и на этом экземпляре")
+		}
+		formChallenge = challenge.New([]byte(secret), minFill, ttl, powBits)
+		log.Printf("✅ Токены формы: не быстрее %s, срок %s, задача %d бит", minFill, ttl, powBits)
+	}
+
+	// Обработчики работают с БД только через хранилище
+	bot := telegram.FromEnv()
+	h := handlers.New(wishStore, feed, worker, bot, registry, backups, handlers.Config{
+		// WISH_MODERATION=true — пожелания появляются публично только после одобрения в боте
+		Moderation: os.Getenv("WISH_MODERATION") == "true",
+		// INVITE_BASE_URL — адрес сайта для персональных ссылок ?invite=...
+		InviteBaseURL: getEnv("INVITE_BASE_URL", "https://wedding-frontend-zt57.onrender.com"),
+		// TELEGRAM_WEBHOOK_SECRET — Telegram присылает его в X-Telegram-Bot-Api-Secret-Token
+		WebhookSecret: webhookSecret,
+		// WEDDING_TZ — часовой пояс свадьбы для дат в выгрузках
+		Location: location,
+		// EXPORT_TOKEN — доступ к GET /api/export
+		ExportToken: os.Getenv("EXPORT_TOKEN"),
+		// TRASH_RETENTION_DAYS — сколько удалённые пожелания лежат в корзине
+		TrashRetention: trashRetention,
+		// SPAM_* — что делать с подозрительными пожеланиями: reject, moderate или off
+		Spam: spamChecks,
+		// WISH_CHALLENGE, CHALLENGE_* — подписанные токены формы пожеланий
+		Challenge: formChallenge,
+	})
+	if webhookSecret == "" {
+		log.Println("⚠️ TELEGRAM_WEBHOOK_SECRET не задан — вебхук принимает запросы от кого угодно")
+	}
+
+	// Меню команд в Telegram и имя бота для команд вида /list@bot — до приёма обновлений
+	if bot.Configured() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if err := h.RegisterCommands(ctx); err != nil {
+			log.Printf("⚠️ Не удалось зарегистрировать команды бота: %v", err)
+		}
+		cancel()
+	}
+
+	// TELEGRAM_MODE=polling — бот сам опрашивает getUpdates (локальная разработка без HTTPS),
+	// иначе Telegram присылает обновления на вебхук
+	polling := os.Getenv("TELEGRAM_MODE") == "polling"
+	if polling && bot.Configured() {
+		poller := &telegram.Poller{Bot: bot, Offsets: wishStore, Timeout: 30}
+		go func() {
+			log.Println("✅ Бот получает обновления через getUpdates")
+			if err := poller.Run(context.Background(), h.HandleUpdate); err != nil {
+				log.Printf("❌ Long polling остановлен: %v", err)
+			}
+		}()
+	}
+
+	// При известном внешнем адресе регистрируем вебхук сами (TELEGRAM_SET_WEBHOOK=false отключает)
+	if webhook := webhookURL(); webhook != "" && !polling && bot.Configured() && os.Getenv("TELEGRAM_SET_WEBHOOK") != "false" {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if err := registerWebhook(ctx, bot, webhook); err != nil {
+			log.Printf("⚠️ Не удалось установить вебхук: %v", err)
+		} else {
+			log.Println("✅ Вебхук Telegram зарегистрирован")
+		}
+		cancel()
+	}
+
+	// BACKUP_INTERVAL (например, 24h) — копия по расписанию уходит владельцам бота в Telegram
+	if v := os.Getenv("BACKUP_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval < time.Minute {
+			log.Fatal("❌ BACKUP_INTERVAL должен быть длительностью не меньше минуты, например 24h")
+		}
+		go backups.Run(context.Background(), interval, h.SendBackup)
+		log.Printf("✅ Резервные копии каждые %s", interval)
+	}
+
+	// Ограничение частоты POST /api/wish: WISH_RATE_LIMIT_IP — с одного адреса,
+	// WISH_RATE_LIMIT_INVITE — по одной персональной ссылке (формат 5/10m, off — без лимита)
+	ipLimit, err := ratelimit.ParseLimit(getEnv("WISH_RATE_LIMIT_IP", "20/10m"))
+	if err != nil {
+		log.Fatal("❌ WISH_RATE_LIMIT_IP: ", err)
+	}
+	inviteLimit, err := ratelimit.ParseLimit(getEnv("WISH_RATE_LIMIT_INVITE", "5/10m"))
+	if err != nil {
+		log.Fatal("❌ WISH_RATE_LIMIT_INVITE: ", err)
+	}
+	// TRUSTED_PROXY_HOPS — сколько прокси перед сервером дописывают X-Forwarded-For (на Render — 1);
+	// без него адрес клиента берётся из соединения, а заголовок не учитывается
+	proxyHops, err := strconv.Atoi(getEnv("TRUSTED_PROXY_HOPS", "0"))
+	if err != nil || proxyHops < 0 {
+		log.Fatal("❌ TRUSTED_PROXY_HOPS должно быть неотрицательным числом")
+	}
+	// RATE_LIMIT_STORE=postgres — общие вёдра для нескольких экземпляров, иначе в памяти процесса
+	var buckets store.RateLimitStore = store.NewMemory()
+	if os.Getenv("RATE_LIMIT_STORE") == "postgres" {
+		buckets = wishStore
+	}
+	limiter := ratelimit.New(buckets, wishStore, ipLimit, inviteLimit, proxyHops)
+	log.Printf("✅ Лимит пожеланий: %s с адреса, %s по приглашению", ipLimit, inviteLimit)
+
 	// Настройка маршрутов
 	mux := http.NewServeMux()
-	mux.HandleFunc("/api/wishes", handlers.GetWishes)
-	mux.HandleFunc("/api/wish", handlers.AddWish)
-	mux.HandleFunc("/telegram", handlers.HandleWebhook)
+	mux.HandleFunc("/api/wishes", h.GetWishes)
+	mux.HandleFunc("/api/wishes/stream", h.StreamWishes)
+	mux.HandleFunc("/api/wish", limiter.Wrap(h.AddWish))
+	mux.HandleFunc("/api/challenge", h.Challenge)
+	mux.HandleFunc("/api/rsvp", h.CreateRSVP)
+	mux.HandleFunc("/api/rsvp/{token}", h.RSVPByToken)
+	mux.HandleFunc("/api/invite/{token}", h.GetInvite)
+	mux.HandleFunc("/api/export", h.Export)
+	mux.HandleFunc(webhookPath(), h.HandleWebhook)
 
 	// Добавляем CORS ко всем маршрутам
 	handler := withCORS(mux)
@@ -99,13 +99,28 @@ export default function App() {
 
   useEffect(() => {
     fetchWishes();
-    
-    const intervalId = setInterval(() => {
-      console.log("🔄 Автоматическое обновление пожеланий...");
+
+    // Без поддержки SSE остаёмся на опросе по таймеру
+    if (typeof EventSource === "undefined") {
+      const intervalId = setInterval(() => {
+        console.log("🔄 Автоматическое обновление пожеланий...");
+        fetchWishes();
+      }, 30000);
+
+      return () => clearInterval(intervalId);
+    }
+
+    // Перечитываем ленту только когда на сервере что-то изменилось
+    const source = new EventSource(`${API_URL}/api/wishes/stream`);
+    const onChange = (event) => {
+      console.log("📨 Событие ленты:", event.type);
       fetchWishes();
-    }, 30000);
-    
-    return () => clearInterval(intervalId);
+    };
+    ["created", "deleted", "restored", "reset"].forEach((type) =>
+      source.addEventListener(type, onChange)
+    );
+
+    return () => source.close();
   }, []);
 
   useEffect(() => {
@@ -1,15 +1,77 @@
 // src/components/GuestbookForm.jsx
-import { useState } from "react";
+import { useCallback, useEffect, useState } from "react";
 import Toast from "./Toast"; // Кастомное уведомление
 
+// Количество ведущих нулевых бит в SHA-256 — так же считает сервер
+async function leadingZeroBits(text) {
+    const digest = new Uint8Array(
+        await crypto.subtle.digest("SHA-256", new TextEncoder().encode(text))
+    );
+    let zeros = 0;
+    for (const byte of digest) {
+        if (byte !== 0) {
+            return zeros + Math.clz32(byte) - 24;
+        }
+        zeros += 8;
+    }
+    return zeros;
+}
+
+// Подбираем решение задачи из /api/challenge: несколько секунд работы браузера для гостя,
+// но дорого для рассылки спама
+async function solveChallenge(challenge) {
+    if (!challenge.difficulty) return "";
+    for (let n = 0; ; n++) {
+        if ((await leadingZeroBits(`${challenge.token}:${n}`)) >= challenge.difficulty) {
+            return String(n);
+        }
+    }
+}
+
 export default function GuestbookForm({ onNewWish }) {
     const [name, setName] = useState("");
     const [message, setMessage] = useState("");
+    // Поле-ловушка: гости его не видят, а боты заполняют — такие пожелания сервер отклоняет
+    const [website, setWebsite] = useState("");
     const [isSubmitting, setIsSubmitting] = useState(false);
     const [toast, setToast] = useState(null);
 
     const API_URL = import.meta.env.VITE_API_URL;
 
+    // Персональная ссылка вида ?invite=TOKEN: имя берём из списка гостей
+    const [inviteToken] = useState(
+        () => new URLSearchParams(window.location.search).get("invite") || ""
+    );
+    const [invitedName, setInvitedName] = useState("");
+
+    useEffect(() => {
+        if (!inviteToken) return;
+
+        fetch(`${API_URL}/api/invite/${encodeURIComponent(inviteToken)}`)
+            .then((res) => (res.ok ? res.json() : null))
+            .then((invite) => {
+                if (invite && invite.name) {
+                    setInvitedName(invite.name);
+                    setName(invite.name);
+                }
+            })
+            .catch((err) => console.error("❌ Ошибка загрузки приглашения:", err));
+    }, [API_URL, inviteToken]);
+
+    // Подписанный токен формы; без него сервер с WISH_CHALLENGE=true пожелание не примет
+    const [challenge, setChallenge] = useState(null);
+
+    const loadChallenge = useCallback(() => {
+        fetch(`${API_URL}/api/challenge`)
+            .then((res) => (res.ok ? res.json() : null))
+            .then(setChallenge)
+            .catch((err) => console.error("❌ Ошибка загрузки токена формы:", err));
+    }, [API_URL]);
+
+    useEffect(() => {
+        loadChallenge();
+    }, [loadChallenge]);
+
     const closeToast = () => setToast(null);
 
     const handleSubmit = async (e) => {
@@ -29,10 +91,18 @@ export default function GuestbookForm({ onNewWish }) {
         setIsSubmitting(true);
         setToast({ message: "Отправляется...", type: "info" });
 
-        const newWish = { name: name.trim(), message: message.trim() };
+        const newWish = { name: name.trim(), message: message.trim(), website };
+        if (invitedName) {
+            newWish.invite_token = inviteToken;
+        }
         console.log("📤 Отправка пожелания:", newWish);
 
         try {
+            if (challenge) {
+                newWish.challenge = challenge.token;
+                newWish.solution = await solveChallenge(challenge);
+            }
+
             const res = await fetch(`${API_URL}/api/wish`, {
                 method: "POST",
                 headers: { "Content-Type": "application/json" },
@@ -42,6 +112,8 @@ export default function GuestbookForm({ onNewWish }) {
             console.log("📡 Статус ответа:", res.status, res.statusText);
 
             if (res.status === 201) {
+                // Токен одноразовый: для следующей отправки берём новый
+                loadChallenge();
                 const savedWish = await res.json();
                 console.log("✅ Успешно сохранено в базу:", savedWish);
 
@@ -52,10 +124,19 @@ export default function GuestbookForm({ onNewWish }) {
                     createdAt: savedWish.created_at || savedWish.createdAt,
                 };
 
-                onNewWish(formattedWish);
-                setName("");
+                // Ожидающее модерации пожелание не показываем в бегущей строке
+                const pending = savedWish.status === "pending";
+                if (!pending) {
+                    onNewWish(formattedWish);
+                }
+                setName(invitedName);
                 setMessage("");
-                setToast({ message: "Пожелание отправлено! 💕", type: "success" });
+                setToast({
+                    message: pending
+                        ? "Спасибо! Пожелание появится после проверки 💕"
+                        : "Пожелание отправлено! 💕",
+                    type: "success",
+                });
             } else {
                 let errorData;
                 const clonedRes = res.clone();
@@ -71,6 +152,11 @@ export default function GuestbookForm({ onNewWish }) {
                     }
                 }
 
+                // 403 — токен устарел или негоден; при других ошибках он ещё годен и пригодится для повтора
+                if (res.status === 403) {
+                    loadChallenge();
+                }
+
                 const errorMsg = errorData.error || "Ошибка на сервере";
                 console.error("❌ Ошибка от сервера:", res.status, errorMsg);
                 setToast({ message: `Ошибка: ${errorMsg}`, type: "error" });
@@ -98,7 +184,17 @@ export default function GuestbookForm({ onNewWish }) {
                         onChange={(e) => setName(e.target.value)}
                         style={formStyles.input}
                         name="name"
-                        disabled={isSubmitting}
+                        disabled={isSubmitting || Boolean(invitedName)}
+                    />
+                    <input
+                        type="text"
+                        value={website}
+                        onChange={(e) => setWebsite(e.target.value)}
+                        style={formStyles.honeypot}
+                        name="website"
+                        tabIndex={-1}
+                        autoComplete="off"
+                        aria-hidden="true"
                     />
                     <textarea
                         placeholder="Ваше тёплое пожелание"
@@ -150,6 +246,13 @@ const formStyles = {
         borderRadius: "8px",
         fontSize: "1rem",
     },
+    honeypot: {
+        position: "absolute",
+        left: "-10000px",
+        width: "1px",
+        height: "1px",
+        opacity: 0,
+    },
     textarea: {
         padding: "12px",
         border: "1px solid #ddd",